@@ -1,41 +1,120 @@
+from typing import Dict
+
 from fastapi import FastAPI, Request, Response
+from fastapi.exceptions import RequestValidationError
 from fastapi.middleware.cors import CORSMiddleware
 from fastapi.responses import JSONResponse
+from starlette.exceptions import HTTPException as StarletteHTTPException
 
+from src.api.errors import error_response
+from src.api.middleware.body_limit import BodyLimitMiddleware
 from src.api.middleware.logging import LoggingMiddleware
-from src.api.routes import health, root
+from src.api.middleware.rate_limit import InMemoryRateLimitStore, RateLimitMiddleware
+from src.api.middleware.recovery import RecoveryMiddleware
+from src.api.middleware.timeout import TimeoutMiddleware
+from src.api.openapi import install_openapi
+from src.api.routes import admin, health, root
+from src.api.schemas import COMMON_ERROR_RESPONSES
+from src.api.versioning import VersionRegistry
 from src.core.config import settings
 from src.utils.logger import logger
+from src.utils.validators import ValidationFailed, field_errors
 
 def create_app(lifespan=None) -> FastAPI:
+    versions = VersionRegistry(base_path=settings.API_PREFIX, deprecated=settings.API_DEPRECATED_VERSIONS)
+    versions.register("v1", admin.router, tags=["admin"])
+
     app = FastAPI(
         title=settings.PROJECT_NAME,
         version=settings.VERSION,
-        openapi_url=f"{settings.API_V1_PREFIX}/openapi.json",
+        openapi_url=f"{versions.prefix('v1')}/openapi.json",
+        docs_url="/docs" if settings.DOCS_ENABLED else None,
+        redoc_url="/redoc" if settings.DOCS_ENABLED else None,
+        responses=COMMON_ERROR_RESPONSES,
         lifespan=lifespan
     )
 
+    # Middleware added first runs innermost. The body limit sits closest to
+    # the routes so its oversize error reaches the app's handlers as a 413,
+    # and recovery sits inside logging so the resulting 500 is still logged.
+    app.add_middleware(BodyLimitMiddleware, max_body_bytes=settings.MAX_REQUEST_BODY_BYTES)
+
+    app.add_middleware(RecoveryMiddleware)
+
+    if settings.RATE_LIMIT_ENABLED:
+        app.add_middleware(
+            RateLimitMiddleware,
+            store=InMemoryRateLimitStore(idle_ttl=settings.RATE_LIMIT_IDLE_TTL),
+            read_limit=settings.RATE_LIMIT_READ_PER_MINUTE,
+            write_limit=settings.RATE_LIMIT_WRITE_PER_MINUTE,
+            trusted_proxies=settings.RATE_LIMIT_TRUSTED_PROXIES,
+        )
+
+    app.add_middleware(
+        TimeoutMiddleware,
+        timeout=settings.REQUEST_TIMEOUT,
+        route_timeouts=settings.REQUEST_TIMEOUT_OVERRIDES,
+    )
+
     app.add_middleware(
         CORSMiddleware,
-        allow_origins=settings.BACKEND_CORS_ORIGINS,
-        allow_credentials=True,
-        allow_methods=["*"],
-        allow_headers=["*"],
+        allow_origins=settings.cors_exact_origins,
+        allow_origin_regex=settings.cors_origin_regex,
+        allow_credentials=settings.BACKEND_CORS_ALLOW_CREDENTIALS,
+        allow_methods=settings.BACKEND_CORS_ALLOW_METHODS,
+        allow_headers=settings.BACKEND_CORS_ALLOW_HEADERS,
+        # ETag isn't CORS-safelisted; without it browsers can't revalidate
+        expose_headers=["X-Request-ID", "X-Process-Time", "ETag"],
+        max_age=settings.BACKEND_CORS_MAX_AGE,
     )
 
-    app.add_middleware(LoggingMiddleware)
+    app.add_middleware(
+        LoggingMiddleware,
+        capture_error_body=settings.LOG_CAPTURE_ERROR_BODY,
+        max_body_capture=settings.LOG_CAPTURE_MAX_BYTES,
+    )
 
     app.include_router(health.router, tags=["health"])
     app.include_router(root.router, tags=["root"])
 
+    versions.mount(app)
+    app.state.api_versions = versions
+    install_openapi(app)
+
     @app.exception_handler(404)
     async def not_found_handler(request: Request, exc):
-        return JSONResponse(
-            status_code=404,
-            content={
-                "error": "Not Found",
-                "message": "The requested resource was not found"
-            }
+        version = versions.unsupported_version(request.url.path)
+        if version is not None:
+            return error_response(
+                404,
+                f"API version '{version}' is not supported",
+                code="UNSUPPORTED_VERSION",
+                details={"supported_versions": versions.versions}
+            )
+
+        return error_response(404, "The requested resource was not found", code="NOT_FOUND")
+
+    # Everything else raised as an HTTPException gets the standard envelope;
+    # APIError adds its code so clients can tell e.g. 413s apart.
+    @app.exception_handler(StarletteHTTPException)
+    async def http_exception_handler(request: Request, exc: StarletteHTTPException):
+        return error_response(
+            exc.status_code,
+            str(exc.detail),
+            code=getattr(exc, "code", None),
+            headers=exc.headers
         )
-        
+
+    def validation_error_response(message: str, fields: Dict[str, str]) -> JSONResponse:
+        return error_response(400, message, code="VALIDATION_ERROR", details={"fields": fields})
+
+    @app.exception_handler(RequestValidationError)
+    async def request_validation_handler(request: Request, exc: RequestValidationError):
+        return validation_error_response("The request failed validation", field_errors(exc.errors(), root="body"))
+
+    # Services raise ValidationFailed for bad input so it fails like a bad request
+    @app.exception_handler(ValidationFailed)
+    async def validation_failed_handler(request: Request, exc: ValidationFailed):
+        return validation_error_response(exc.message, exc.fields)
+
     return app
\ No newline at end of file
@@ -0,0 +1,31 @@
+from http import HTTPStatus
+from typing import Any, Dict, Optional
+from fastapi import HTTPException
+from fastapi.responses import JSONResponse
+from src.utils.logger import get_request_id
+
+class APIError(HTTPException):
+    # An HTTPException that also carries a machine-readable code for clients
+    def __init__(self, status_code: int, code: str, message: str, headers: Optional[Dict[str, str]] = None):
+        super().__init__(status_code=status_code, detail=message, headers=headers)
+        self.code = code
+
+def error_response(
+    status_code: int,
+    message: str,
+    code: Optional[str] = None,
+    headers: Optional[Dict[str, str]] = None,
+    details: Optional[Dict[str, Any]] = None
+) -> JSONResponse:
+    # Every error body in the API is built here so it matches ErrorResponse;
+    # details adds the extra fields some errors document (fields, versions).
+    # Without an explicit code, the status name stands in, e.g. UNAUTHORIZED.
+    status = HTTPStatus(status_code)
+    content: Dict[str, Any] = {
+        "error": status.phrase,
+        "code": code or status.name,
+        "message": message,
+        **(details or {}),
+        "request_id": get_request_id()
+    }
+    return JSONResponse(status_code=status_code, content=content, headers=headers)
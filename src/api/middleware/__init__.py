@@ -0,0 +1,2 @@
+# Methods that change state; rate limits and body checks treat these as writes
+WRITE_METHODS = frozenset({"POST", "PUT", "PATCH", "DELETE"})
@@ -0,0 +1,51 @@
+from starlette.datastructures import Headers
+from starlette.types import ASGIApp, Message, Receive, Scope, Send
+from src.api.errors import APIError, error_response
+from src.api.middleware import WRITE_METHODS
+
+def is_json(content_type: str) -> bool:
+    media_type = content_type.split(";")[0].strip().lower()
+    return media_type == "application/json" or media_type.endswith("+json")
+
+class BodyLimitMiddleware:
+    def __init__(self, app: ASGIApp, max_body_bytes: int):
+        self.app = app
+        self.max_body_bytes = max_body_bytes
+
+    def too_large(self) -> str:
+        return f"Request body exceeds {self.max_body_bytes} bytes"
+
+    async def __call__(self, scope: Scope, receive: Receive, send: Send):
+        if scope["type"] != "http":
+            await self.app(scope, receive, send)
+            return
+
+        headers = Headers(scope=scope)
+        content_length = headers.get("content-length")
+        has_body = (content_length not in (None, "0")) or "transfer-encoding" in headers
+
+        if scope["method"] in WRITE_METHODS and has_body and not is_json(headers.get("content-type", "")):
+            response = error_response(415, "Request body must be application/json", code="UNSUPPORTED_MEDIA_TYPE")
+            await response(scope, receive, send)
+            return
+
+        if content_length and content_length.isdigit() and int(content_length) > self.max_body_bytes:
+            response = error_response(413, self.too_large(), code="PAYLOAD_TOO_LARGE")
+            await response(scope, receive, send)
+            return
+
+        # Content-Length can be absent (chunked) or wrong, so count as we read.
+        # FastAPI turns anything but an HTTPException raised while reading the
+        # body into a 400, so the overflow has to be one to surface as a 413.
+        received = 0
+
+        async def receive_wrapper() -> Message:
+            nonlocal received
+            message = await receive()
+            if message["type"] == "http.request":
+                received += len(message.get("body", b""))
+                if received > self.max_body_bytes:
+                    raise APIError(413, "PAYLOAD_TOO_LARGE", self.too_large())
+            return message
+
+        await self.app(scope, receive_wrapper, send)
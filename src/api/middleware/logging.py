@@ -1,36 +1,80 @@
+import re
 import time
 from uuid import uuid4
 from fastapi import Request
-from starlette.middleware.base import BaseHTTPMiddleware
-from src.utils.logger import logger
+from starlette.datastructures import MutableHeaders
+from starlette.types import ASGIApp, Message, Receive, Scope, Send
+from src.utils.logger import logger, request_id_var
 
-class LoggingMiddleware(BaseHTTPMiddleware):
-    async def dispatch(self, request: Request, call_next):
-        request_id = str(uuid4())
+# Client-supplied IDs are echoed into headers and logs, so keep them tame
+REQUEST_ID_PATTERN = re.compile(r"^[A-Za-z0-9._-]{1,128}$")
+
+def resolve_request_id(request: Request) -> str:
+    incoming = request.headers.get("X-Request-ID")
+    if incoming and REQUEST_ID_PATTERN.match(incoming):
+        return incoming
+    return str(uuid4())
+
+# Plain ASGI rather than BaseHTTPMiddleware so streamed responses pass
+# through untouched while we count the bytes sent.
+class LoggingMiddleware:
+    def __init__(self, app: ASGIApp, capture_error_body: bool = False, max_body_capture: int = 4096):
+        self.app = app
+        self.capture_error_body = capture_error_body
+        self.max_body_capture = max_body_capture
+
+    async def __call__(self, scope: Scope, receive: Receive, send: Send):
+        if scope["type"] != "http":
+            await self.app(scope, receive, send)
+            return
+
+        request = Request(scope)
+        request_id = resolve_request_id(request)
+        request.state.request_id = request_id
+        token = request_id_var.set(request_id)
         start_time = time.time()
 
-        logger.info({
-            "request_id": request_id,
-            "method": request.method,
-            "url": str(request.url),
-            "user_agent": request.headers.get("user_agent"),
-            "message": "Incomfing request"
-        })
+        status_code = 500
+        response_size = 0
+        captured = bytearray()
+
+        async def send_wrapper(message: Message) -> None:
+            nonlocal status_code, response_size
 
-        response = await call_next(request)
+            if message["type"] == "http.response.start":
+                status_code = message["status"]
+                headers = MutableHeaders(scope=message)
+                headers["X-Request-ID"] = request_id
+                headers["X-Process-Time"] = str(time.time() - start_time)
+            elif message["type"] == "http.response.body":
+                body = message.get("body", b"")
+                response_size += len(body)
+                if self.capture_error_body and status_code >= 500:
+                    remaining = self.max_body_capture - len(captured)
+                    if remaining > 0:
+                        captured.extend(body[:remaining])
 
-        process_time = time.time() - start_time
+            await send(message)
 
-        logger.info({
-            "request_id": request_id,
-            "method": request.method,
-            "url": str(reuqest.url),
-            "status_code": request.status_code,
-            "process_time": f"{process_time:.3f}",
-            "message": "Request Completed"
-        })
+        try:
+            await self.app(scope, receive, send_wrapper)
+        finally:
+            process_time = time.time() - start_time
 
-        response.headers["X-Request-ID"] = request_id
-        response.headers["X-Process-Time"] = str(process_time)
+            entry = {
+                "request_id": request_id,
+                "method": request.method,
+                "url": str(request.url),
+                "status_code": status_code,
+                "user_agent": request.headers.get("user-agent"),
+                "response_size": response_size,
+                "process_time": f"{process_time:.3f}",
+                "message": "Request Completed"
+            }
+            if captured:
+                entry["response_body"] = captured.decode("utf-8", errors="replace")
 
-        return response
\ No newline at end of file
+            # The only line per request, written once the outcome is known;
+            # never sampled, so a 5xx can't hide behind a 200
+            logger.info(entry, extra={"access": True})
+            request_id_var.reset(token)
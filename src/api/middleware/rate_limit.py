@@ -0,0 +1,136 @@
+import ipaddress
+import math
+import threading
+import time
+from dataclasses import dataclass
+from typing import Dict, Iterable, List, Protocol, Tuple, Union
+
+from starlette.datastructures import Headers, MutableHeaders
+from starlette.types import ASGIApp, Message, Receive, Scope, Send
+from src.api.errors import error_response
+from src.api.middleware import WRITE_METHODS
+
+Network = Union[ipaddress.IPv4Network, ipaddress.IPv6Network]
+
+
+@dataclass
+class RateLimitResult:
+    allowed: bool
+    limit: int
+    remaining: int
+    retry_after: float
+
+
+class RateLimitStore(Protocol):
+    def hit(self, key: str, limit: int, period: float) -> RateLimitResult:
+        ...
+
+
+class InMemoryRateLimitStore:
+    def __init__(self, idle_ttl: float = 600.0):
+        self.idle_ttl = idle_ttl
+        self._buckets: Dict[str, Tuple[float, float]] = {}
+        self._lock = threading.Lock()
+        self._last_sweep = time.monotonic()
+
+    def hit(self, key: str, limit: int, period: float) -> RateLimitResult:
+        now = time.monotonic()
+        refill_rate = limit / period
+
+        with self._lock:
+            self._sweep(now)
+
+            tokens, updated_at = self._buckets.get(key, (float(limit), now))
+            tokens = min(float(limit), tokens + (now - updated_at) * refill_rate)
+
+            if tokens < 1:
+                self._buckets[key] = (tokens, now)
+                return RateLimitResult(False, limit, 0, (1 - tokens) / refill_rate)
+
+            tokens -= 1
+            self._buckets[key] = (tokens, now)
+            return RateLimitResult(True, limit, int(tokens), 0.0)
+
+    def _sweep(self, now: float) -> None:
+        # Drop buckets for clients that stopped calling so memory stays bounded
+        if now - self._last_sweep < self.idle_ttl:
+            return
+        self._last_sweep = now
+        expired = [k for k, (_, updated_at) in self._buckets.items() if now - updated_at > self.idle_ttl]
+        for key in expired:
+            del self._buckets[key]
+
+
+class RateLimitMiddleware:
+    def __init__(
+        self,
+        app: ASGIApp,
+        store: RateLimitStore,
+        read_limit: int,
+        write_limit: int,
+        period: float = 60.0,
+        exempt_paths: Tuple[str, ...] = ("/health",),
+        trusted_proxies: Iterable[str] = (),
+    ):
+        self.app = app
+        self.store = store
+        self.read_limit = read_limit
+        self.write_limit = write_limit
+        self.period = period
+        self.exempt_paths = exempt_paths
+        self.trusted_proxies: List[Network] = [ipaddress.ip_network(p, strict=False) for p in trusted_proxies]
+
+    def is_trusted(self, address: str) -> bool:
+        try:
+            ip = ipaddress.ip_address(address)
+        except ValueError:
+            return False
+        return any(ip in network for network in self.trusted_proxies)
+
+    def client_key(self, scope: Scope) -> str:
+        peer = scope["client"][0] if scope.get("client") else "unknown"
+        if not self.is_trusted(peer):
+            return peer
+
+        # Behind trusted proxies the client is the right-most address in
+        # X-Forwarded-For that we didn't add ourselves; anything to its left
+        # is client-controlled and can't be trusted.
+        forwarded = Headers(scope=scope).get("x-forwarded-for", "")
+        hops = [h.strip() for h in forwarded.split(",") if h.strip()]
+        for hop in reversed(hops):
+            if not self.is_trusted(hop):
+                return hop
+        return hops[0] if hops else peer
+
+    async def __call__(self, scope: Scope, receive: Receive, send: Send):
+        if scope["type"] != "http" or scope["path"].startswith(self.exempt_paths):
+            await self.app(scope, receive, send)
+            return
+
+        group = "write" if scope["method"] in WRITE_METHODS else "read"
+        limit = self.write_limit if group == "write" else self.read_limit
+
+        result = self.store.hit(f"{group}:{self.client_key(scope)}", limit, self.period)
+
+        if not result.allowed:
+            response = error_response(
+                429,
+                "Rate limit exceeded, please retry later",
+                code="RATE_LIMITED",
+                headers={
+                    "Retry-After": str(math.ceil(result.retry_after)),
+                    "X-RateLimit-Limit": str(result.limit),
+                    "X-RateLimit-Remaining": "0"
+                }
+            )
+            await response(scope, receive, send)
+            return
+
+        async def send_wrapper(message: Message) -> None:
+            if message["type"] == "http.response.start":
+                headers = MutableHeaders(scope=message)
+                headers["X-RateLimit-Limit"] = str(result.limit)
+                headers["X-RateLimit-Remaining"] = str(result.remaining)
+            await send(message)
+
+        await self.app(scope, receive, send_wrapper)
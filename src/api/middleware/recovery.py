@@ -0,0 +1,46 @@
+from starlette.types import ASGIApp, Message, Receive, Scope, Send
+from src.api.errors import error_response
+from src.utils.logger import logger
+
+# Plain ASGI so the exception stops here; BaseHTTPMiddleware re-raises it after
+# dispatch returns, which makes the server log it again and drop the connection.
+class RecoveryMiddleware:
+    def __init__(self, app: ASGIApp):
+        self.app = app
+
+    async def __call__(self, scope: Scope, receive: Receive, send: Send):
+        if scope["type"] != "http":
+            await self.app(scope, receive, send)
+            return
+
+        response_started = False
+        response_complete = False
+
+        async def send_wrapper(message: Message) -> None:
+            nonlocal response_started, response_complete
+            if message["type"] == "http.response.start":
+                response_started = True
+            elif message["type"] == "http.response.body" and not message.get("more_body", False):
+                response_complete = True
+            await send(message)
+
+        try:
+            await self.app(scope, receive, send_wrapper)
+        except Exception:
+            logger.exception({
+                "method": scope["method"],
+                "path": scope["path"],
+                "response_started": response_started,
+                "response_complete": response_complete,
+                "message": "Unhandled exception"
+            })
+
+            # A background task or dependency cleanup failed after the response
+            # went out: nothing more may be sent. If the body is mid-stream,
+            # leave it unterminated so the server drops the connection and the
+            # client sees a truncated response rather than a clean one.
+            if response_started:
+                return
+
+            response = error_response(500, "An unexpected error occurred", code="INTERNAL_ERROR")
+            await response(scope, receive, send)
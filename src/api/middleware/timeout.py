@@ -0,0 +1,80 @@
+import asyncio
+from typing import Dict, Optional
+from starlette.types import ASGIApp, Message, Receive, Scope, Send
+from src.api.errors import error_response
+from src.utils.logger import logger
+
+class TimeoutMiddleware:
+    def __init__(self, app: ASGIApp, timeout: float, route_timeouts: Optional[Dict[str, float]] = None):
+        self.app = app
+        self.timeout = timeout
+        # Path prefix -> seconds; the longest matching prefix wins and 0 disables
+        self.route_timeouts = sorted((route_timeouts or {}).items(), key=lambda i: len(i[0]), reverse=True)
+
+    def timeout_for(self, path: str) -> float:
+        for prefix, timeout in self.route_timeouts:
+            if path.startswith(prefix):
+                return timeout
+        return self.timeout
+
+    async def __call__(self, scope: Scope, receive: Receive, send: Send):
+        timeout = self.timeout_for(scope["path"]) if scope["type"] == "http" else 0
+        if timeout <= 0:
+            await self.app(scope, receive, send)
+            return
+
+        response_started = False
+        response_complete = asyncio.Event()
+        timed_out = False
+
+        async def send_wrapper(message: Message) -> None:
+            nonlocal response_started
+            # Anything the handler tries to write after the deadline is dropped
+            if timed_out:
+                return
+            if message["type"] == "http.response.start":
+                response_started = True
+            elif message["type"] == "http.response.body" and not message.get("more_body", False):
+                response_complete.set()
+            await send(message)
+
+        # The deadline covers producing the response, not what runs after it:
+        # Starlette runs background tasks inside the same app call once the
+        # body is sent, and those must not be cancelled or answered again.
+        task = asyncio.ensure_future(self.app(scope, receive, send_wrapper))
+        completed = asyncio.ensure_future(response_complete.wait())
+        try:
+            await asyncio.wait({task, completed}, timeout=timeout, return_when=asyncio.FIRST_COMPLETED)
+        except asyncio.CancelledError:
+            task.cancel()
+            raise
+        finally:
+            completed.cancel()
+
+        if task.done() or response_complete.is_set():
+            await task
+            return
+
+        timed_out = True
+        task.cancel()
+        try:
+            await task
+        except asyncio.CancelledError:
+            pass
+
+        logger.warning({
+            "method": scope["method"],
+            "path": scope["path"],
+            "timeout": timeout,
+            "response_started": response_started,
+            "message": "Request timed out"
+        })
+
+        if response_started:
+            # Headers are already out, so a 504 is impossible. Leave the body
+            # unterminated: the server then drops the connection and the client
+            # sees a truncated response instead of a clean one.
+            return
+
+        response = error_response(504, "The request took too long to process", code="TIMEOUT")
+        await response(scope, receive, send)
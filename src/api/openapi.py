@@ -0,0 +1,34 @@
+from typing import Any, Dict
+from fastapi import FastAPI
+from src.api.schemas import BODY_ERROR_RESPONSES
+
+def install_openapi(app: FastAPI) -> None:
+    # FastAPI documents a 422 on every route with parameters or a body, but
+    # validation errors are returned as 400s (see COMMON_ERROR_RESPONSES).
+    # Rewrite the generated schema once so it matches what clients receive.
+    generate = app.openapi
+
+    def openapi() -> Dict[str, Any]:
+        if app.openapi_schema:
+            return app.openapi_schema
+
+        schema = generate()
+        for path_item in schema.get("paths", {}).values():
+            for operation in path_item.values():
+                responses = operation.setdefault("responses", {})
+                responses.pop("422", None)
+                if "requestBody" in operation:
+                    for status, description in BODY_ERROR_RESPONSES.items():
+                        responses.setdefault(status, {
+                            "description": description,
+                            "content": {"application/json": {"schema": {"$ref": "#/components/schemas/ErrorResponse"}}},
+                        })
+
+        components = schema.get("components", {}).get("schemas", {})
+        for name in ("HTTPValidationError", "ValidationError"):
+            components.pop(name, None)
+
+        app.openapi_schema = schema
+        return schema
+
+    app.openapi = openapi  # type: ignore[method-assign]
@@ -0,0 +1,67 @@
+import hashlib
+from datetime import datetime, timezone
+from email.utils import format_datetime, parsedate_to_datetime
+from typing import Any, Optional
+from fastapi import Request, Response
+from fastapi.responses import JSONResponse
+
+def make_etag(body: bytes) -> str:
+    return f'W/"{hashlib.sha256(body).hexdigest()[:32]}"'
+
+def etag_matches(etag: str, if_none_match: str) -> bool:
+    # Weak comparison per RFC 9110: the W/ prefix is ignored on both sides
+    if if_none_match.strip() == "*":
+        return True
+    target = etag.removeprefix("W/")
+    return any(tag.strip().removeprefix("W/") == target for tag in if_none_match.split(","))
+
+def not_modified_since(last_modified: datetime, if_modified_since: str) -> bool:
+    try:
+        since = parsedate_to_datetime(if_modified_since)
+    except (TypeError, ValueError):
+        return False
+    if since.tzinfo is None:
+        since = since.replace(tzinfo=timezone.utc)
+    if last_modified.tzinfo is None:
+        last_modified = last_modified.replace(tzinfo=timezone.utc)
+    # HTTP dates have second precision
+    return last_modified.replace(microsecond=0) <= since
+
+def conditional_json_response(
+    request: Request,
+    content: Any,
+    last_modified: Optional[datetime] = None,
+    status_code: int = 200,
+) -> Response:
+    response = JSONResponse(status_code=status_code, content=content)
+
+    # A 304 only stands in for a successful read; a POST or a 201 must always
+    # get its real response, whatever validators the client sent.
+    if request.method != "GET" or status_code != 200:
+        return response
+
+    # Hash the serialized payload so the ETag changes exactly when the body does
+    etag = make_etag(response.body)
+
+    headers = {"ETag": etag}
+    if last_modified is not None:
+        if last_modified.tzinfo is None:
+            last_modified = last_modified.replace(tzinfo=timezone.utc)
+        headers["Last-Modified"] = format_datetime(last_modified.astimezone(timezone.utc), usegmt=True)
+
+    if_none_match = request.headers.get("If-None-Match")
+    if_modified_since = request.headers.get("If-Modified-Since")
+
+    # If-Modified-Since is only consulted when no If-None-Match is sent
+    if if_none_match is not None:
+        not_modified = etag_matches(etag, if_none_match)
+    elif if_modified_since is not None and last_modified is not None:
+        not_modified = not_modified_since(last_modified, if_modified_since)
+    else:
+        not_modified = False
+
+    if not_modified:
+        return Response(status_code=304, headers=headers)
+
+    response.headers.update(headers)
+    return response
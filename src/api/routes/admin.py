@@ -0,0 +1,49 @@
+import logging
+import secrets
+from typing import Dict, Literal, Optional
+from fastapi import APIRouter, Depends, Header, HTTPException
+from src.api.schemas import ErrorResponse, NotFoundResponse, RequestModel
+from src.core.config import settings
+from src.utils.logger import get_level, get_suppressed_count, logger, set_level
+
+async def require_admin(x_admin_key: Optional[str] = Header(default=None)) -> None:
+    if not settings.ADMIN_API_KEY:
+        raise HTTPException(status_code=404)
+    if not x_admin_key or not secrets.compare_digest(x_admin_key, settings.ADMIN_API_KEY):
+        raise HTTPException(status_code=401, detail="Invalid or missing admin key")
+
+router = APIRouter(
+    prefix="/admin",
+    dependencies=[Depends(require_admin)],
+    responses={
+        401: {"model": ErrorResponse, "description": "Invalid or missing admin key"},
+        404: {"model": NotFoundResponse, "description": "Admin API disabled or API version not supported"},
+    }
+)
+
+class LogLevelUpdate(RequestModel):
+    level: Literal["debug", "info", "warning", "error", "critical"]
+
+@router.get("/log-level")
+async def read_log_level() -> Dict[str, str]:
+    return {"level": get_level()}
+
+@router.put("/log-level")
+async def update_log_level(update: LogLevelUpdate) -> Dict[str, str]:
+    previous = get_level()
+
+    # Log before switching, at a level the current setting lets through, so
+    # raising the level to error or critical can't hide its own audit line.
+    logger.log(
+        max(logging.WARNING, logger.getEffectiveLevel()),
+        {"message": "Log level changed", "previous": previous, "new_level": update.level},
+        extra={"audit": True}
+    )
+    set_level(update.level)
+
+    return {"level": get_level()}
+
+@router.get("/log-stats")
+async def read_log_stats() -> Dict[str, int]:
+    # Total records dropped by log sampling since startup
+    return {"suppressed": get_suppressed_count()}
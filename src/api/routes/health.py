@@ -1,6 +1,8 @@
 from datetime import datetime
 from typing import Dict, Any
 from fastapi import APIRouter
+from fastapi.responses import JSONResponse
+from src.core.health import health_registry
 
 router = APIRouter()
 
@@ -12,6 +14,33 @@ async def health_check() -> Dict[str, Any]:
          "uptime": get_uptime()
     }
 
+@router.get("/health/live")
+async def liveness() -> Dict[str, Any]:
+    return {
+        "status": "alive",
+        "timestamp": datetime.utcnow().isoformat()
+    }
+
+@router.get("/health/ready")
+async def readiness() -> JSONResponse:
+    ready, checks = await health_registry.run()
+
+    if health_registry.draining:
+        status = "draining"
+    elif ready:
+        status = "ready"
+    else:
+        status = "not_ready"
+
+    return JSONResponse(
+        status_code=200 if ready else 503,
+        content={
+            "status": status,
+            "timestamp": datetime.utcnow().isoformat(),
+            "checks": checks
+        }
+    )
+
 def get_uptime() -> float:
     import os
     import time
@@ -1,12 +1,13 @@
 from typing import Dict
-from fastapi import APIRouter
+from fastapi import APIRouter, Request, Response
+from src.api.responses import conditional_json_response
 from src.core.config import settings
 
 router = APIRouter()
 
-@router.get("/")
-async def root() -> Dict[str, str]:
-    return {
+@router.get("/", response_model=Dict[str, str], responses={304: {"description": "Not Modified"}})
+async def root(request: Request) -> Response:
+    return conditional_json_response(request, {
         "message": "Welcome to alya.io",
         "version": settings.VERSION
-    }
\ No newline at end of file
+    })
\ No newline at end of file
@@ -0,0 +1,36 @@
+from typing import Any, Dict, List, Optional, Union
+from pydantic import BaseModel, ConfigDict
+
+class RequestModel(BaseModel):
+    # Reject unknown fields so a typo like "ulr" is an error, not a no-op
+    model_config = ConfigDict(extra="forbid")
+
+class ErrorResponse(BaseModel):
+    error: str
+    # Stable machine-readable identifier, e.g. RATE_LIMITED or TIMEOUT
+    code: str
+    message: str
+    request_id: Optional[str] = None
+
+class NotFoundResponse(ErrorResponse):
+    # Set when the path names an API version that isn't served
+    supported_versions: Optional[List[str]] = None
+
+class ValidationErrorResponse(ErrorResponse):
+    code: str = "VALIDATION_ERROR"
+    fields: Dict[str, str]
+
+# Documented on every route; these come from middleware and app-wide handlers
+COMMON_ERROR_RESPONSES: Dict[Union[int, str], Dict[str, Any]] = {
+    400: {"model": ValidationErrorResponse, "description": "Validation Error"},
+    429: {"model": ErrorResponse, "description": "Rate Limited"},
+    500: {"model": ErrorResponse, "description": "Internal Server Error"},
+    504: {"model": ErrorResponse, "description": "Request Timed Out"},
+}
+
+# Only operations that take a body can hit these, so they are added per
+# operation when the schema is built rather than to every route
+BODY_ERROR_RESPONSES: Dict[str, str] = {
+    "413": "Request Body Too Large",
+    "415": "Unsupported Media Type",
+}
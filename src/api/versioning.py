@@ -0,0 +1,76 @@
+import re
+from typing import Any, Dict, List, Optional, Tuple
+from fastapi import APIRouter, FastAPI
+from starlette.datastructures import MutableHeaders
+from starlette.types import ASGIApp, Message, Receive, Scope, Send
+from src.api.schemas import NotFoundResponse
+
+class DeprecationMiddleware:
+    def __init__(self, app: ASGIApp, prefixes: Dict[str, Optional[str]]):
+        self.app = app
+        # Path prefix -> Sunset date (HTTP-date), or None when no date is set
+        self.prefixes = prefixes
+
+    async def __call__(self, scope: Scope, receive: Receive, send: Send):
+        sunset = None
+        deprecated = False
+        if scope["type"] == "http":
+            for prefix, date in self.prefixes.items():
+                if scope["path"] == prefix or scope["path"].startswith(prefix + "/"):
+                    deprecated, sunset = True, date
+                    break
+
+        if not deprecated:
+            await self.app(scope, receive, send)
+            return
+
+        async def send_wrapper(message: Message) -> None:
+            if message["type"] == "http.response.start":
+                headers = MutableHeaders(scope=message)
+                headers["Deprecation"] = "true"
+                if sunset:
+                    headers["Sunset"] = sunset
+            await send(message)
+
+        await self.app(scope, receive, send_wrapper)
+
+VERSION_PATTERN = re.compile(r"^v\d+$")
+
+class VersionRegistry:
+    def __init__(self, base_path: str = "/api", deprecated: Optional[Dict[str, str]] = None):
+        self.base_path = base_path.rstrip("/")
+        self.deprecated = deprecated or {}
+        self._routers: Dict[str, List[Tuple[APIRouter, Dict[str, Any]]]] = {}
+
+    @property
+    def versions(self) -> List[str]:
+        return sorted(self._routers)
+
+    def prefix(self, version: str) -> str:
+        return f"{self.base_path}/{version}"
+
+    def register(self, version: str, router: APIRouter, **kwargs: Any) -> None:
+        self._routers.setdefault(version, []).append((router, kwargs))
+
+    def mount(self, app: FastAPI) -> None:
+        for version, routers in self._routers.items():
+            for router, kwargs in routers:
+                # Every versioned route can answer with the unsupported-version 404
+                options = dict(kwargs)
+                responses = {404: {"model": NotFoundResponse, "description": "Not Found"}, **options.pop("responses", {})}
+                app.include_router(router, prefix=self.prefix(version), responses=responses, **options)
+
+        deprecated = {self.prefix(v): self.deprecated[v] or None for v in self.deprecated if v in self._routers}
+        if deprecated:
+            app.add_middleware(DeprecationMiddleware, prefixes=deprecated)
+
+    def unsupported_version(self, path: str) -> Optional[str]:
+        # Returns the version segment of a path under base_path that has no
+        # registered routers, so 404s can tell clients what is supported. Only
+        # segments shaped like a version count; /api/foo is a plain 404.
+        if not path.startswith(self.base_path + "/"):
+            return None
+        version = path[len(self.base_path) + 1:].split("/", 1)[0]
+        if not VERSION_PATTERN.match(version) or version in self._routers:
+            return None
+        return version
@@ -1,7 +1,8 @@
 import os
-from typing import List, Union
+import re
+from typing import Dict, List, Optional, Union
 
-from pydantic import field_validator
+from pydantic import Field, field_validator, model_validator
 from pydantic_settings import BaseSettings
 
 class Settings(BaseSettings):
@@ -17,25 +18,93 @@ class Settings(BaseSettings):
     HOST: str = "0.0.0.0"
     PORT: int = 8000
     WORKERS: int = 1
+    SHUTDOWN_TIMEOUT: float = 10.0
+    # Seconds to keep serving after SIGTERM while readiness reports draining
+    SHUTDOWN_DRAIN_DELAY: float = 0.0
+    HEALTH_CHECK_TIMEOUT: float = 2.0
     
     # API
-    API_V1_PREFIX: str = "/api/v1"
+    API_PREFIX: str = "/api"
+    # Version -> Sunset HTTP-date ("" for none); responses get Deprecation headers
+    API_DEPRECATED_VERSIONS: Dict[str, str] = {}
+    # Seconds per request; overrides are keyed by path prefix and 0 disables
+    REQUEST_TIMEOUT: float = 30.0
+    REQUEST_TIMEOUT_OVERRIDES: Dict[str, float] = {}
+    MAX_REQUEST_BODY_BYTES: int = 1024 * 1024
+    # Serve Swagger UI and ReDoc; the OpenAPI document is always served
+    DOCS_ENABLED: bool = True
     
-    # CORS
+    # CORS (origins may use a wildcard subdomain, e.g. https://*.example.com)
     BACKEND_CORS_ORIGINS: List[str] = ["http://localhost:3000", "http://localhost:8000"]
+    BACKEND_CORS_ALLOW_METHODS: List[str] = ["GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"]
+    BACKEND_CORS_ALLOW_HEADERS: List[str] = [
+        "Authorization", "Content-Type", "X-Request-ID", "If-None-Match", "If-Modified-Since"
+    ]
+    BACKEND_CORS_ALLOW_CREDENTIALS: bool = True
+    BACKEND_CORS_MAX_AGE: int = 600
+    
+    # Admin endpoints are disabled unless a key is configured
+    ADMIN_API_KEY: Optional[str] = None
+    
+    # Rate limiting (requests per minute, per client). Off by default: behind a
+    # proxy every request shares the proxy's address unless it is listed in
+    # RATE_LIMIT_TRUSTED_PROXIES (IPs or CIDRs), which enables X-Forwarded-For.
+    RATE_LIMIT_ENABLED: bool = False
+    RATE_LIMIT_TRUSTED_PROXIES: List[str] = []
+    RATE_LIMIT_READ_PER_MINUTE: int = 120
+    RATE_LIMIT_WRITE_PER_MINUTE: int = 30
+    RATE_LIMIT_IDLE_TTL: int = 600
     
     # Logging
     LOG_LEVEL: str = "info"
     LOG_FORMAT: str = "json"
+    LOG_FILE: Optional[str] = None
+    # Both must be positive: zero disables rotation and the file grows unbounded
+    LOG_MAX_SIZE_MB: float = Field(default=100, gt=0)
+    LOG_MAX_BACKUPS: int = Field(default=5, ge=1)
+    LOG_MAX_AGE_DAYS: float = 0
+    # Repeats of a message within the window beyond the burst are dropped and
+    # counted; 0 disables sampling. Records at or above the floor always pass.
+    LOG_SAMPLING_WINDOW: float = 0
+    LOG_SAMPLING_BURST: int = 1
+    LOG_SAMPLING_FLOOR: str = "error"
+    LOG_CAPTURE_ERROR_BODY: bool = False
+    LOG_CAPTURE_MAX_BYTES: int = 4096
     
-    @field_validator("BACKEND_CORS_ORIGINS", mode="before")
+    @field_validator(
+        "BACKEND_CORS_ORIGINS",
+        "BACKEND_CORS_ALLOW_METHODS",
+        "BACKEND_CORS_ALLOW_HEADERS",
+        "RATE_LIMIT_TRUSTED_PROXIES",
+        mode="before"
+    )
     @classmethod
-    def assemble_cors_origins(cls, v: Union[str, List[str]]) -> Union[List[str], str]:
+    def assemble_list(cls, v: Union[str, List[str]]) -> Union[List[str], str]:
         if isinstance(v, str) and not v.startswith("["):
             return [i.strip() for i in v.split(",")]
         elif isinstance(v, (list, str)):
             return v
         raise ValueError(v)
+
+    @model_validator(mode="after")
+    def check_cors_credentials(self) -> "Settings":
+        # Browsers refuse credentialed responses with a wildcard origin
+        if self.BACKEND_CORS_ALLOW_CREDENTIALS and "*" in self.BACKEND_CORS_ORIGINS:
+            raise ValueError("BACKEND_CORS_ORIGINS cannot contain '*' when BACKEND_CORS_ALLOW_CREDENTIALS is enabled")
+        return self
+
+    @property
+    def cors_exact_origins(self) -> List[str]:
+        return [o for o in self.BACKEND_CORS_ORIGINS if "*." not in o]
+
+    @property
+    def cors_origin_regex(self) -> Optional[str]:
+        patterns = [
+            re.escape(o).replace(r"\*\.", r"[A-Za-z0-9-]+(?:\.[A-Za-z0-9-]+)*\.")
+            for o in self.BACKEND_CORS_ORIGINS
+            if "*." in o
+        ]
+        return "|".join(patterns) if patterns else None
     
     class Config:
         env_file = ".env"
@@ -0,0 +1,55 @@
+import asyncio
+import time
+from typing import Any, Awaitable, Callable, Dict, Tuple
+
+from src.core.config import settings
+
+Check = Callable[[], Awaitable[None]]
+
+
+class HealthRegistry:
+    def __init__(self, timeout: float = 2.0):
+        self.timeout = timeout
+        self.ready = False
+        self.draining = False
+        self._checks: Dict[str, Check] = {}
+
+    def register(self, name: str, check: Check) -> None:
+        # A check signals failure by raising; returning normally means healthy
+        self._checks[name] = check
+
+    def mark_ready(self) -> None:
+        self.ready = True
+
+    def mark_draining(self) -> None:
+        self.draining = True
+
+    # Lifecycle hooks: registered last so the instance reports ready only once
+    # everything else is up, and starts draining before anything is stopped.
+    async def start(self) -> None:
+        self.mark_ready()
+
+    async def stop(self) -> None:
+        self.mark_draining()
+
+    async def run(self) -> Tuple[bool, Dict[str, Dict[str, Any]]]:
+        names = list(self._checks)
+        results = await asyncio.gather(*(self._run_check(self._checks[n]) for n in names))
+        checks = dict(zip(names, results))
+        healthy = all(c["status"] == "ok" for c in checks.values())
+        return healthy and self.ready and not self.draining, checks
+
+    async def _run_check(self, check: Check) -> Dict[str, Any]:
+        start_time = time.time()
+        try:
+            await asyncio.wait_for(check(), timeout=self.timeout)
+            result: Dict[str, Any] = {"status": "ok"}
+        except asyncio.TimeoutError:
+            result = {"status": "error", "error": f"timed out after {self.timeout}s"}
+        except Exception as exc:
+            result = {"status": "error", "error": str(exc) or exc.__class__.__name__}
+        result["duration_ms"] = round((time.time() - start_time) * 1000, 1)
+        return result
+
+
+health_registry = HealthRegistry(timeout=settings.HEALTH_CHECK_TIMEOUT)
@@ -0,0 +1,82 @@
+import asyncio
+import time
+from dataclasses import dataclass
+from typing import Awaitable, Callable, List, Optional
+
+from src.utils.logger import logger
+
+Hook = Callable[[], Awaitable[None]]
+
+
+@dataclass
+class Component:
+    name: str
+    start: Optional[Hook] = None
+    stop: Optional[Hook] = None
+    stop_timeout: Optional[float] = None
+
+
+class Lifecycle:
+    def __init__(self, stop_timeout: float = 10.0):
+        self.stop_timeout = stop_timeout
+        self._components: List[Component] = []
+        self._started: List[Component] = []
+
+    def register(
+        self,
+        name: str,
+        start: Optional[Hook] = None,
+        stop: Optional[Hook] = None,
+        stop_timeout: Optional[float] = None,
+    ) -> None:
+        self._components.append(Component(name, start, stop, stop_timeout))
+
+    async def start(self) -> None:
+        for component in self._components:
+            if component.start is not None:
+                try:
+                    await component.start()
+                except Exception as exc:
+                    logger.error({
+                        "message": "Component failed to start",
+                        "component": component.name,
+                        "error": repr(exc)
+                    })
+                    # Unwind whatever already started before surfacing the error
+                    await self.stop()
+                    raise
+            self._started.append(component)
+            logger.info({"message": "Component started", "component": component.name})
+
+    async def stop(self) -> None:
+        while self._started:
+            component = self._started.pop()
+            if component.stop is None:
+                continue
+
+            # None falls back to the default; zero or less waits without a limit
+            timeout = self.stop_timeout if component.stop_timeout is None else component.stop_timeout
+            limit = timeout if timeout > 0 else None
+            start_time = time.time()
+            try:
+                await asyncio.wait_for(component.stop(), timeout=limit)
+            except asyncio.TimeoutError:
+                logger.warning({
+                    "message": "Component did not stop in time",
+                    "component": component.name,
+                    "timeout": timeout
+                })
+                continue
+            except Exception as exc:
+                logger.error({
+                    "message": "Component failed to stop",
+                    "component": component.name,
+                    "error": repr(exc)
+                })
+                continue
+
+            logger.info({
+                "message": "Component stopped",
+                "component": component.name,
+                "stop_time": f"{time.time() - start_time:.3f}"
+            })
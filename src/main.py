@@ -1,33 +1,70 @@
 import os
-import signal
-import sys
+import threading
+from typing import Optional
 from contextlib import asynccontextmanager
 import uvicorn
 from dotenv import load_dotenv
 
 from src.api.app import create_app
-from src.utils.logger import logger 
+from src.core.config import settings
+from src.core.health import health_registry
+from src.core.lifecycle import Lifecycle
+from src.utils.logger import flush_sampling, logger
 
 load_dotenv()
 
+async def flush_log_sampling() -> None:
+    flush_sampling()
+
+lifecycle = Lifecycle(stop_timeout=settings.SHUTDOWN_TIMEOUT)
+# Components start in registration order and stop in reverse; readiness
+# goes last so it flips before anything else is torn down, and log sampling
+# goes first so its pending counts are reported after everything else stops.
+lifecycle.register("log-sampling", stop=flush_log_sampling)
+lifecycle.register("readiness", start=health_registry.start, stop=health_registry.stop)
+
 @asynccontextmanager
 async def lifespan(app):
     # Startup
     logger.info({"message": "Starting up...", "port": os.getenv("PORT", 8000)})
+    await lifecycle.start()
     yield
-    # Shutdown
+    # Shutdown (uvicorn handles SIGINT/SIGTERM and runs this on exit)
     logger.info("Shutting down...")
+    await lifecycle.stop()
 
 app = create_app(lifespan=lifespan)
 
-def signal_handler(sig, frame):
-    logger.info(f"Received signal {sig}")
-    sys.exit(0)
+class Server(uvicorn.Server):
+    # uvicorn closes the listener as soon as it sees the signal. Flip readiness
+    # first and keep serving for SHUTDOWN_DRAIN_DELAY so load balancers stop
+    # routing to us; a second signal cuts the drain short. Only runs when
+    # started through this module: the uvicorn CLI uses its own Server.
+    drain_timer: Optional[threading.Timer] = None
 
-if __name__ == "__main__":
-    signal.signal(signal.SIGTERM, signal_handler)
-    signal.signal(signal.SIGINT, signal_handler)
+    def handle_exit(self, sig, frame):
+        if self.drain_timer is not None:
+            # Cancel the pending exit, or it would fire mid-shutdown and look
+            # like a second Ctrl+C, forcing uvicorn to quit without cleanup
+            self.drain_timer.cancel()
+            self.drain_timer = None
+            return super().handle_exit(sig, frame)
+
+        if health_registry.draining or settings.SHUTDOWN_DRAIN_DELAY <= 0:
+            health_registry.mark_draining()
+            return super().handle_exit(sig, frame)
 
+        health_registry.mark_draining()
+        logger.info({"message": "Draining before shutdown", "signal": sig, "delay": settings.SHUTDOWN_DRAIN_DELAY})
+        self.drain_timer = threading.Timer(settings.SHUTDOWN_DRAIN_DELAY, self.finish_drain, args=(sig, frame))
+        self.drain_timer.daemon = True
+        self.drain_timer.start()
+
+    def finish_drain(self, sig, frame):
+        self.drain_timer = None
+        super().handle_exit(sig, frame)
+
+if __name__ == "__main__":
     port = int(os.getenv("PORT", 8000))
     host = os.getenv("HOST", "0.0.0.0")
     reload = os.getenv("ENV", "development") == "development"
@@ -39,11 +76,13 @@ if __name__ == "__main__":
         "environment": os.getenv("ENV", "development")
     })
 
-    uvicorn.run(
-        "src.main:app",
-        host=host,
-        port=port,
-        reload=reload,
-        log_config=None
-    )
-
+    if reload:
+        uvicorn.run(
+            "src.main:app",
+            host=host,
+            port=port,
+            reload=reload,
+            log_config=None
+        )
+    else:
+        Server(uvicorn.Config(app, host=host, port=port, log_config=None)).run()
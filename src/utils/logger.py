@@ -1,7 +1,206 @@
+import json
 import logging
+import logging.handlers
+import os
 import sys
-from typing import Any
+import threading
+import time
+from contextvars import ContextVar
+from datetime import datetime, timezone
+from typing import Any, Optional
 from pydantic import BaseModel
+from src.core.config import Settings, settings
+
+request_id_var: ContextVar[Optional[str]] = ContextVar("request_id", default=None)
+
+def get_request_id() -> Optional[str]:
+    return request_id_var.get()
+
+class JsonFormatter(logging.Formatter):
+    # Fixed keys come first in a stable order; dict messages are flattened
+    # after them in the order their keys were written. A field that collides
+    # with a fixed key is kept under "fields.<key>" rather than dropped.
+    RESERVED_KEYS = frozenset({"ts", "level", "logger", "caller", "msg", "request_id", "stack"})
+
+    def format(self, record: logging.LogRecord) -> str:
+        entry: dict[str, Any] = {
+            "ts": datetime.fromtimestamp(record.created, tz=timezone.utc).isoformat(),
+            "level": record.levelname.lower(),
+            "logger": record.name,
+            "caller": f"{record.module}:{record.funcName}:{record.lineno}",
+        }
+
+        fields: dict[str, Any] = {}
+        if isinstance(record.msg, dict):
+            fields = dict(record.msg)
+            entry["msg"] = str(fields.pop("message", ""))
+        else:
+            entry["msg"] = record.getMessage()
+
+        request_id = fields.pop("request_id", None) or get_request_id()
+        if request_id:
+            entry["request_id"] = request_id
+
+        for key, value in fields.items():
+            entry[f"fields.{key}" if key in self.RESERVED_KEYS else key] = value
+
+        if record.exc_info:
+            entry["stack"] = self.formatException(record.exc_info).splitlines()
+        elif record.stack_info:
+            entry["stack"] = record.stack_info.splitlines()
+
+        return json.dumps(entry, default=str)
+
+
+class SamplingFilter(logging.Filter):
+    # Collapses repeats of an identical message from the same call site within
+    # a window to the first `burst` records. Once the window closes, a summary
+    # record reports how many were dropped; flush() reports any still pending.
+    # Records at or above floor_level, and those marked as audit or access-log
+    # lines, are never sampled.
+    EXEMPT_MARKERS = ("audit", "access")
+
+    def __init__(self, window: float = 1.0, burst: int = 1, floor_level: int = logging.ERROR):
+        super().__init__()
+        self.window = window
+        self.burst = burst
+        self.floor_level = floor_level
+        self.suppressed_total = 0
+        # key -> [window start, records let through, records suppressed, funcName]
+        self._seen: dict[tuple, list] = {}
+        self._last_sweep = time.monotonic()
+        self._lock = threading.Lock()
+
+    @staticmethod
+    def sampling_key(record: logging.LogRecord) -> tuple:
+        # Dict messages are identical when every field but the request ID
+        # matches; plain messages compare by their unformatted template.
+        if isinstance(record.msg, dict):
+            text = str(record.msg.get("message", ""))
+            fields = tuple(sorted(
+                (k, repr(v)) for k, v in record.msg.items() if k not in ("message", "request_id")
+            ))
+        else:
+            text, fields = str(record.msg), ()
+        return (record.name, record.levelno, record.pathname, record.lineno, text, fields)
+
+    def filter(self, record: logging.LogRecord) -> bool:
+        if (self.window <= 0 or record.levelno >= self.floor_level
+                or any(getattr(record, marker, False) for marker in self.EXEMPT_MARKERS)):
+            return True
+
+        key = self.sampling_key(record)
+        now = time.monotonic()
+
+        with self._lock:
+            expired = self._sweep(now)
+            state = self._seen.get(key)
+            if state is not None and now - state[0] >= self.window:
+                if state[2]:
+                    expired.append((key, state))
+                state = None
+
+            if state is None:
+                self._seen[key] = [now, 1, 0, record.funcName]
+                allowed = True
+            elif state[1] < self.burst:
+                state[1] += 1
+                allowed = True
+            else:
+                state[2] += 1
+                self.suppressed_total += 1
+                allowed = False
+
+        for expired_key, expired_state in expired:
+            self._emit_summary(expired_key, expired_state)
+        return allowed
+
+    def flush(self) -> None:
+        with self._lock:
+            pending = [(k, v) for k, v in self._seen.items() if v[2]]
+            self._seen.clear()
+        for key, state in pending:
+            self._emit_summary(key, state)
+
+    def _sweep(self, now: float) -> list:
+        # Drops closed windows at most once per window, keeping memory bounded
+        # and collecting the ones that still owe a summary.
+        if now - self._last_sweep < self.window:
+            return []
+        self._last_sweep = now
+        expired = []
+        for key, state in list(self._seen.items()):
+            if now - state[0] >= self.window:
+                del self._seen[key]
+                if state[2]:
+                    expired.append((key, state))
+        return expired
+
+    def _emit_summary(self, key: tuple, state: list) -> None:
+        name, levelno, pathname, lineno, text, _ = key
+        summary = logging.LogRecord(name, levelno, pathname, lineno, {
+            "message": "Suppressed repeated log messages",
+            "sampled_message": text,
+            "suppressed": state[2],
+            "window": self.window
+        }, None, None, func=state[3])
+        # Straight to the handlers: this usually runs inside the logger's own
+        # filter pass, where logging ignores re-entrant handle() calls.
+        logging.getLogger(name).callHandlers(summary)
+
+
+class RotatingFileHandler(logging.handlers.RotatingFileHandler):
+    # Size-based rotation that also prunes backups past max_age_days and
+    # reopens the file if something outside the process moved or deleted it.
+    def __init__(self, filename: str, max_bytes: int = 0, backup_count: int = 0,
+                 max_age_days: float = 0, encoding: Optional[str] = "utf-8"):
+        super().__init__(filename, maxBytes=max_bytes, backupCount=backup_count,
+                         encoding=encoding, delay=False)
+        self.max_age_days = max_age_days
+        self._stat_stream()
+
+    def _stat_stream(self) -> None:
+        if self.stream is None:
+            self._dev, self._ino = -1, -1
+            return
+        st = os.fstat(self.stream.fileno())
+        self._dev, self._ino = st.st_dev, st.st_ino
+
+    def _reopen_if_needed(self) -> None:
+        try:
+            st = os.stat(self.baseFilename)
+            changed = st.st_dev != self._dev or st.st_ino != self._ino
+        except FileNotFoundError:
+            changed = True
+        if changed and self.stream is not None:
+            self.stream.flush()
+            self.stream.close()
+            self.stream = self._open()
+            self._stat_stream()
+
+    def emit(self, record: logging.LogRecord) -> None:
+        # emit is called with the handler lock held, so rotation and reopen
+        # are serialized across threads.
+        self._reopen_if_needed()
+        super().emit(record)
+
+    def doRollover(self) -> None:
+        super().doRollover()
+        self._stat_stream()
+        self._prune_old_backups()
+
+    def _prune_old_backups(self) -> None:
+        if self.max_age_days <= 0:
+            return
+        cutoff = time.time() - self.max_age_days * 86400
+        for i in range(1, self.backupCount + 1):
+            backup = f"{self.baseFilename}.{i}"
+            try:
+                if os.path.getmtime(backup) < cutoff:
+                    os.remove(backup)
+            except FileNotFoundError:
+                continue
+
 
 class LogConfig(BaseModel):
     LOGGER_NAME: str = "alya.io"
@@ -16,8 +215,13 @@ class LogConfig(BaseModel):
             "format": LOG_FORMAT,
             "datefmt": "%Y-%m-%d %H:%M:%S",
         },
+        "json": {
+            "()": JsonFormatter,
+        },
     }
 
+    filters: dict[str, Any] = {}
+
     handlers: dict[str, Any] = {
         "default": {
             "formatter": "default",
@@ -31,12 +235,61 @@ class LogConfig(BaseModel):
     }
 
 
-def setup_logging():
-    import os
+def setup_logging(options: Settings = settings):
     from logging.config import dictConfig
     config = LogConfig()
-    config.LOG_LEVEL = os.getenv("LOG_LEVEL", "INFO").upper()
+    config.LOG_LEVEL = options.LOG_LEVEL.upper()
+    config.loggers[config.LOGGER_NAME]["level"] = config.LOG_LEVEL
+    if options.LOG_FORMAT.lower() == "json":
+        config.handlers["default"]["formatter"] = "json"
+
+    # The filter sits on the logger rather than the handlers: it is stateful,
+    # so a shared instance would see every record once per handler and drop
+    # the copies after the first.
+    if options.LOG_SAMPLING_WINDOW > 0:
+        config.filters["sampling"] = {
+            "()": SamplingFilter,
+            "window": options.LOG_SAMPLING_WINDOW,
+            "burst": options.LOG_SAMPLING_BURST,
+            "floor_level": logging.getLevelName(options.LOG_SAMPLING_FLOOR.upper()),
+        }
+        config.loggers[config.LOGGER_NAME]["filters"] = ["sampling"]
+
+    # LOG_FILE tees output to a rotating file alongside stdout
+    if options.LOG_FILE:
+        config.handlers["file"] = {
+            "()": RotatingFileHandler,
+            "formatter": config.handlers["default"]["formatter"],
+            "filename": options.LOG_FILE,
+            "max_bytes": int(options.LOG_MAX_SIZE_MB * 1024 * 1024),
+            "backup_count": options.LOG_MAX_BACKUPS,
+            "max_age_days": options.LOG_MAX_AGE_DAYS,
+        }
+        config.loggers[config.LOGGER_NAME]["handlers"] = ["default", "file"]
+
+    # dictConfig replaces handlers but only ever adds logger filters
+    existing = logging.getLogger(config.LOGGER_NAME)
+    for f in [f for f in existing.filters if isinstance(f, SamplingFilter)]:
+        existing.removeFilter(f)
+
     dictConfig(config.model_dump())
     return logging.getLogger(config.LOGGER_NAME)
 
+def get_level() -> str:
+    return logging.getLevelName(logger.level).lower()
+
+def set_level(level: str) -> None:
+    # Child loggers have no level of their own and resolve through this one,
+    # so the change applies to every logger derived from it immediately.
+    logger.setLevel(level.upper())
+
+def get_suppressed_count() -> int:
+    return sum(f.suppressed_total for f in logger.filters if isinstance(f, SamplingFilter))
+
+def flush_sampling() -> None:
+    # Reports suppressed counts for windows that haven't closed yet
+    for f in logger.filters:
+        if isinstance(f, SamplingFilter):
+            f.flush()
+
 logger = setup_logging()
\ No newline at end of file
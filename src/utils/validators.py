@@ -0,0 +1,48 @@
+import re
+from typing import Annotated, Any, Dict, Iterable, Mapping, Type, TypeVar
+from pydantic import AfterValidator, BaseModel, ValidationError
+
+Model = TypeVar("Model", bound=BaseModel)
+
+YOUTUBE_ID_PATTERN = re.compile(r"^[A-Za-z0-9_-]{11}$")
+# ISO 639 language with optional region/script subtags, e.g. en, en-US, zh-Hant
+LANGUAGE_CODE_PATTERN = re.compile(r"^[A-Za-z]{2,3}(-[A-Za-z0-9]{2,8})*$")
+
+def validate_youtube_id(value: str) -> str:
+    if not YOUTUBE_ID_PATTERN.match(value):
+        raise ValueError("must be an 11 character YouTube video ID")
+    return value
+
+def validate_language_code(value: str) -> str:
+    if not LANGUAGE_CODE_PATTERN.match(value):
+        raise ValueError("must be an ISO 639 language code such as 'en' or 'en-US'")
+    primary, _, rest = value.partition("-")
+    return primary.lower() + (f"-{rest}" if rest else "")
+
+# Field types for domain models, e.g. `video_id: YouTubeID`
+YouTubeID = Annotated[str, AfterValidator(validate_youtube_id)]
+LanguageCode = Annotated[str, AfterValidator(validate_language_code)]
+
+class ValidationFailed(Exception):
+    # Raised by services when their input is invalid; the API maps it to the
+    # same 400 VALIDATION_ERROR body as a malformed request. Other pydantic
+    # errors are bugs in our own code and stay 500s.
+    def __init__(self, fields: Dict[str, str], message: str = "The request failed validation"):
+        super().__init__(message)
+        self.fields = fields
+        self.message = message
+
+def field_errors(errors: Iterable[Mapping[str, Any]], root: str = "_") -> Dict[str, str]:
+    # Flattens pydantic error locations to dotted paths, e.g. "segments.0.start";
+    # errors on the model as a whole are keyed by root.
+    fields: Dict[str, str] = {}
+    for error in errors:
+        loc = [str(p) for p in error["loc"] if p != "body"]
+        fields[".".join(loc) or root] = error["msg"]
+    return fields
+
+def validate(model: Type[Model], data: Any) -> Model:
+    try:
+        return model.model_validate(data)
+    except ValidationError as exc:
+        raise ValidationFailed(field_errors(exc.errors())) from exc
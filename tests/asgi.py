@@ -0,0 +1,76 @@
+import asyncio
+import json
+from dataclasses import dataclass, field
+from typing import Any, Dict, List, Optional
+
+
+@dataclass
+class ASGIResponse:
+    status: int
+    headers: Dict[str, str]
+    body: bytes
+    # Every message the app sent, for checks on framing such as more_body
+    messages: List[Dict[str, Any]] = field(default_factory=list)
+
+    def json(self) -> Any:
+        return json.loads(self.body)
+
+
+def request(
+    app,
+    method: str,
+    path: str,
+    headers: Optional[Dict[str, str]] = None,
+    body: bytes = b"",
+    chunks: Optional[List[bytes]] = None,
+    client: str = "127.0.0.1",
+) -> ASGIResponse:
+    # Drives the ASGI app directly so tests need nothing beyond the app's own
+    # dependencies; any exception escaping the app fails the test.
+    parts = chunks if chunks is not None else [body]
+    raw_headers = [(k.lower().encode(), v.encode()) for k, v in (headers or {}).items()]
+    if chunks is None and body:
+        raw_headers.append((b"content-length", str(len(body)).encode()))
+    path_only, _, query = path.partition("?")
+
+    scope = {
+        "type": "http",
+        "asgi": {"version": "3.0"},
+        "http_version": "1.1",
+        "method": method,
+        "scheme": "http",
+        "path": path_only,
+        "raw_path": path_only.encode(),
+        "query_string": query.encode(),
+        "root_path": "",
+        "headers": raw_headers,
+        "client": (client, 50000),
+        "server": ("testserver", 80),
+    }
+
+    async def run() -> ASGIResponse:
+        incoming = [
+            {"type": "http.request", "body": part, "more_body": i < len(parts) - 1}
+            for i, part in enumerate(parts)
+        ]
+        sent: List[Dict[str, Any]] = []
+
+        async def receive():
+            if incoming:
+                return incoming.pop(0)
+            return {"type": "http.disconnect"}
+
+        async def send(message):
+            sent.append(message)
+
+        await app(scope, receive, send)
+
+        start = next(m for m in sent if m["type"] == "http.response.start")
+        return ASGIResponse(
+            status=start["status"],
+            headers={k.decode().lower(): v.decode() for k, v in start.get("headers", [])},
+            body=b"".join(m.get("body", b"") for m in sent if m["type"] == "http.response.body"),
+            messages=sent,
+        )
+
+    return asyncio.run(run())
@@ -0,0 +1,24 @@
+import logging
+import timeit
+
+from src.utils.logger import JsonFormatter, LogConfig
+
+ITERATIONS = 50_000
+
+
+def make_record():
+    msg = {"message": "Request Completed", "method": "GET", "url": "/api/v1/videos", "status_code": 200}
+    return logging.LogRecord("alya.io", logging.INFO, __file__, 10, msg, None, None, func="dispatch")
+
+
+def bench(formatter: logging.Formatter) -> float:
+    record = make_record()
+    return timeit.timeit(lambda: formatter.format(record), number=ITERATIONS) / ITERATIONS * 1e6
+
+
+if __name__ == "__main__":
+    text_format = LogConfig().formatters["default"]
+    text = bench(logging.Formatter(text_format["format"], text_format["datefmt"]))
+    json_ = bench(JsonFormatter())
+    print(f"text: {text:.2f}us/op")
+    print(f"json: {json_:.2f}us/op ({json_ / text:.1f}x text)")
@@ -0,0 +1,45 @@
+import logging
+
+import pytest
+
+from src.core.config import settings
+from src.utils.logger import logger
+
+ADMIN_KEY = "test-admin-key"
+
+
+class LogCapture(logging.Handler):
+    # Keeps records instead of writing them so tests can assert on fields
+    def __init__(self):
+        super().__init__()
+        self.records = []
+
+    def emit(self, record):
+        self.records.append(record)
+
+    def messages(self, text=None):
+        # Dict messages only, optionally those whose "message" equals text
+        return [
+            r.msg for r in self.records
+            if isinstance(r.msg, dict) and (text is None or r.msg.get("message") == text)
+        ]
+
+
+@pytest.fixture
+def log_capture():
+    return LogCapture()
+
+
+@pytest.fixture
+def captured_logs(log_capture):
+    logger.addHandler(log_capture)
+    yield log_capture
+    logger.removeHandler(log_capture)
+
+
+@pytest.fixture
+def admin_headers(monkeypatch):
+    # Enables the admin API and returns headers that authenticate against it;
+    # request it before building the app.
+    monkeypatch.setattr(settings, "ADMIN_API_KEY", ADMIN_KEY)
+    return {"X-Admin-Key": ADMIN_KEY}
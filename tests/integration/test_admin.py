@@ -0,0 +1,53 @@
+import pytest
+
+from src.api.app import create_app
+from src.core.config import settings
+from src.utils.logger import get_level, set_level
+from tests.asgi import request
+
+PATH = "/api/v1/admin/log-level"
+
+
+@pytest.fixture
+def app(admin_headers):
+    return create_app()
+
+
+def test_admin_api_is_hidden_without_key(monkeypatch):
+    monkeypatch.setattr(settings, "ADMIN_API_KEY", None)
+
+    response = request(create_app(), "GET", PATH)
+
+    assert response.status == 404
+
+
+def test_admin_api_rejects_wrong_key(app):
+    response = request(app, "GET", PATH, headers={"X-Admin-Key": "wrong"})
+
+    assert response.status == 401
+    assert response.json()["code"] == "UNAUTHORIZED"
+
+
+def test_raising_level_still_logs_the_change(app, admin_headers, captured_logs):
+    previous = get_level()
+    try:
+        response = request(
+            app, "PUT", PATH,
+            headers={**admin_headers, "Content-Type": "application/json"},
+            body=b'{"level": "critical"}'
+        )
+    finally:
+        set_level(previous)
+
+    assert response.status == 200
+    assert response.json() == {"level": "critical"}
+    assert captured_logs.messages("Log level changed") == [
+        {"message": "Log level changed", "previous": previous, "new_level": "critical"}
+    ]
+
+
+def test_log_stats_reports_suppressed_count(app, admin_headers):
+    response = request(app, "GET", "/api/v1/admin/log-stats", headers=admin_headers)
+
+    assert response.status == 200
+    assert isinstance(response.json()["suppressed"], int)
@@ -0,0 +1,71 @@
+import json
+
+import pytest
+
+from src.api.app import create_app
+from src.core.config import settings
+from src.utils.logger import get_level
+from tests.asgi import request
+
+PATH = "/api/v1/admin/log-level"
+
+
+@pytest.fixture
+def app(monkeypatch, admin_headers):
+    monkeypatch.setattr(settings, "MAX_REQUEST_BODY_BYTES", 64)
+    return create_app()
+
+
+def put(app, body, content_type="application/json", chunks=None):
+    headers = {"X-Admin-Key": settings.ADMIN_API_KEY, "Content-Type": content_type}
+    if chunks is not None:
+        headers["Transfer-Encoding"] = "chunked"
+    return request(app, "PUT", PATH, headers=headers, body=body, chunks=chunks)
+
+
+def test_body_within_limit_is_accepted(app):
+    response = put(app, json.dumps({"level": get_level()}).encode())
+
+    assert response.status == 200
+
+
+def test_content_length_over_limit_returns_413(app):
+    response = put(app, b"{" + b" " * 100 + b"}")
+
+    assert response.status == 413
+    body = response.json()
+    assert body["code"] == "PAYLOAD_TOO_LARGE"
+    assert "64 bytes" in body["message"]
+
+
+def test_chunked_body_over_limit_returns_413(app):
+    # No Content-Length, so only counting while reading can catch this
+    response = put(app, b"", chunks=[b'{"level": "', b"x" * 100, b'"}'])
+
+    assert response.status == 413
+    assert response.json()["code"] == "PAYLOAD_TOO_LARGE"
+
+
+def test_non_json_body_returns_415(app):
+    response = put(app, b"level=debug", content_type="text/plain")
+
+    assert response.status == 415
+    assert response.json()["code"] == "UNSUPPORTED_MEDIA_TYPE"
+
+
+def test_unknown_field_is_rejected(app):
+    response = put(app, json.dumps({"level": get_level(), "ulr": "x"}).encode())
+
+    assert response.status == 400
+    body = response.json()
+    assert body["code"] == "VALIDATION_ERROR"
+    assert "ulr" in body["fields"]
+
+
+def test_empty_body_is_rejected(app):
+    response = put(app, b"")
+
+    assert response.status == 400
+    body = response.json()
+    assert body["code"] == "VALIDATION_ERROR"
+    assert "body" in body["fields"]
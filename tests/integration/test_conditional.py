@@ -0,0 +1,143 @@
+from datetime import datetime, timezone
+
+from fastapi import Request
+
+from src.api.app import create_app
+from src.api.responses import conditional_json_response
+from tests.asgi import request
+
+
+UPDATED_AT = datetime(2024, 3, 1, 12, 30, 15, 250000, tzinfo=timezone.utc)
+UPDATED_AT_HTTP = "Fri, 01 Mar 2024 12:30:15 GMT"
+
+
+def build_app():
+    app = create_app()
+
+    @app.get("/items/1")
+    async def item(request: Request):
+        return conditional_json_response(request, {"id": 1}, last_modified=UPDATED_AT)
+
+    @app.post("/items", status_code=201)
+    async def create(request: Request):
+        return conditional_json_response(request, {"id": 1}, status_code=201)
+
+    return app
+
+
+def etag_of(app):
+    return request(app, "GET", "/").headers["etag"]
+
+
+def test_get_returns_weak_etag():
+    response = request(create_app(), "GET", "/")
+
+    assert response.status == 200
+    assert response.headers["etag"].startswith('W/"')
+
+
+def test_matching_etag_returns_304():
+    app = create_app()
+    etag = etag_of(app)
+
+    response = request(app, "GET", "/", headers={"If-None-Match": etag})
+
+    assert response.status == 304
+    assert response.body == b""
+    assert response.headers["etag"] == etag
+
+
+def test_strong_form_of_weak_etag_matches():
+    app = create_app()
+    etag = etag_of(app)
+
+    response = request(app, "GET", "/", headers={"If-None-Match": etag.removeprefix("W/")})
+
+    assert response.status == 304
+
+
+def test_non_matching_etag_returns_full_response():
+    response = request(create_app(), "GET", "/", headers={"If-None-Match": 'W/"stale"'})
+
+    assert response.status == 200
+    assert response.json()["message"] == "Welcome to alya.io"
+
+
+def test_any_of_multiple_etags_can_match():
+    app = create_app()
+    etag = etag_of(app)
+
+    response = request(app, "GET", "/", headers={"If-None-Match": f'W/"stale", {etag}, "other"'})
+
+    assert response.status == 304
+
+
+def test_wildcard_matches():
+    response = request(create_app(), "GET", "/", headers={"If-None-Match": "*"})
+
+    assert response.status == 304
+
+
+def test_non_get_ignores_validators():
+    response = request(
+        build_app(), "POST", "/items",
+        headers={"If-None-Match": "*", "Content-Type": "application/json"},
+    )
+
+    assert response.status == 201
+    assert response.json() == {"id": 1}
+
+
+def test_last_modified_is_sent_as_http_date():
+    response = request(build_app(), "GET", "/items/1")
+
+    assert response.status == 200
+    assert response.headers["last-modified"] == UPDATED_AT_HTTP
+
+
+def test_unchanged_since_returns_304():
+    # Sub-second precision on the server must not defeat an equal HTTP date
+    response = request(build_app(), "GET", "/items/1", headers={"If-Modified-Since": UPDATED_AT_HTTP})
+
+    assert response.status == 304
+    assert response.headers["last-modified"] == UPDATED_AT_HTTP
+
+
+def test_modified_since_returns_full_response():
+    earlier = "Thu, 29 Feb 2024 00:00:00 GMT"
+
+    response = request(build_app(), "GET", "/items/1", headers={"If-Modified-Since": earlier})
+
+    assert response.status == 200
+    assert response.json() == {"id": 1}
+
+
+def test_malformed_modified_since_is_ignored():
+    response = request(build_app(), "GET", "/items/1", headers={"If-Modified-Since": "yesterday"})
+
+    assert response.status == 200
+
+
+def test_if_none_match_takes_precedence_over_modified_since():
+    response = request(
+        build_app(), "GET", "/items/1",
+        headers={"If-None-Match": 'W/"stale"', "If-Modified-Since": UPDATED_AT_HTTP},
+    )
+
+    assert response.status == 200
+
+
+def test_etag_is_exposed_to_cross_origin_clients():
+    response = request(create_app(), "GET", "/", headers={"Origin": "http://localhost:3000"})
+
+    assert "etag" in response.headers["access-control-expose-headers"].lower()
+
+
+def test_preflight_allows_conditional_headers():
+    response = request(create_app(), "OPTIONS", "/", headers={
+        "Origin": "http://localhost:3000",
+        "Access-Control-Request-Method": "GET",
+        "Access-Control-Request-Headers": "if-none-match, if-modified-since",
+    })
+
+    assert response.status == 200
@@ -0,0 +1,51 @@
+import pytest
+
+from src.api.app import create_app
+from src.core.config import settings
+from tests.asgi import request
+
+
+@pytest.fixture
+def app(monkeypatch):
+    monkeypatch.setattr(settings, "BACKEND_CORS_ORIGINS", ["http://localhost:3000", "https://*.example.com"])
+    monkeypatch.setattr(settings, "BACKEND_CORS_ALLOW_METHODS", ["GET", "PUT"])
+    monkeypatch.setattr(settings, "BACKEND_CORS_ALLOW_HEADERS", ["Content-Type", "X-Request-ID"])
+    monkeypatch.setattr(settings, "BACKEND_CORS_MAX_AGE", 123)
+    return create_app()
+
+
+def preflight(app, origin, method="PUT", headers="content-type"):
+    return request(app, "OPTIONS", "/", headers={
+        "Origin": origin,
+        "Access-Control-Request-Method": method,
+        "Access-Control-Request-Headers": headers,
+    })
+
+
+def test_preflight_returns_configured_policy(app):
+    response = preflight(app, "https://app.example.com")
+
+    assert response.status == 200
+    assert response.headers["access-control-allow-origin"] == "https://app.example.com"
+    assert set(response.headers["access-control-allow-methods"].split(", ")) == {"GET", "PUT"}
+    assert set(response.headers["access-control-allow-headers"].lower().split(", ")) >= {"content-type", "x-request-id"}
+    assert response.headers["access-control-max-age"] == "123"
+    assert response.headers["access-control-allow-credentials"] == "true"
+
+
+def test_preflight_accepts_exact_origin(app):
+    response = preflight(app, "http://localhost:3000")
+
+    assert response.status == 200
+
+
+def test_preflight_rejects_unknown_origin(app):
+    response = preflight(app, "https://example.com")
+
+    assert response.status == 400
+
+
+def test_preflight_rejects_unconfigured_method(app):
+    response = preflight(app, "https://app.example.com", method="DELETE")
+
+    assert response.status == 400
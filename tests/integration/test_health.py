@@ -0,0 +1,75 @@
+import asyncio
+
+import pytest
+
+from src.api.app import create_app
+from src.core.health import health_registry
+from tests.asgi import request
+
+
+@pytest.fixture
+def registry(monkeypatch):
+    # The readiness route reads the shared registry; isolate its state
+    monkeypatch.setattr(health_registry, "ready", False)
+    monkeypatch.setattr(health_registry, "draining", False)
+    monkeypatch.setattr(health_registry, "timeout", 0.05)
+    monkeypatch.setattr(health_registry, "_checks", {})
+    return health_registry
+
+
+def test_ready_is_503_before_start(registry):
+    response = request(create_app(), "GET", "/health/ready")
+
+    assert response.status == 503
+    assert response.json()["status"] == "not_ready"
+
+
+def test_ready_is_200_once_started(registry):
+    registry.mark_ready()
+
+    response = request(create_app(), "GET", "/health/ready")
+
+    assert response.status == 200
+    assert response.json()["status"] == "ready"
+
+
+def test_ready_reports_draining(registry):
+    registry.mark_ready()
+    registry.mark_draining()
+
+    response = request(create_app(), "GET", "/health/ready")
+
+    assert response.status == 503
+    assert response.json()["status"] == "draining"
+
+
+def test_failing_check_is_reported(registry):
+    async def failing():
+        raise ConnectionError("database unreachable")
+
+    registry.mark_ready()
+    registry.register("database", failing)
+
+    response = request(create_app(), "GET", "/health/ready")
+
+    assert response.status == 503
+    assert response.json()["checks"]["database"]["error"] == "database unreachable"
+
+
+def test_slow_check_times_out(registry):
+    async def hanging():
+        await asyncio.sleep(1)
+
+    registry.mark_ready()
+    registry.register("upstream", hanging)
+
+    response = request(create_app(), "GET", "/health/ready")
+
+    assert response.status == 503
+    assert response.json()["checks"]["upstream"]["error"] == "timed out after 0.05s"
+
+
+def test_liveness_ignores_readiness(registry):
+    response = request(create_app(), "GET", "/health/live")
+
+    assert response.status == 200
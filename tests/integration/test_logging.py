@@ -0,0 +1,42 @@
+from fastapi.responses import StreamingResponse
+
+from src.api.app import create_app
+from src.core.config import settings
+from tests.asgi import request
+
+
+def build_app():
+    app = create_app()
+
+    @app.get("/boom")
+    async def boom():
+        raise RuntimeError("boom")
+
+    @app.get("/stream")
+    async def stream():
+        async def chunks():
+            for part in (b"one,", b"two,", b"three"):
+                yield part
+
+        return StreamingResponse(chunks(), media_type="text/plain")
+
+    return app
+
+
+def test_streamed_response_is_logged_with_its_size(captured_logs):
+    response = request(build_app(), "GET", "/stream")
+
+    entry = captured_logs.messages("Request Completed")[0]
+    assert entry["status_code"] == 200
+    assert entry["response_size"] == len(response.body) == 13
+
+
+def test_unhandled_error_logs_the_500_body(monkeypatch, captured_logs):
+    monkeypatch.setattr(settings, "LOG_CAPTURE_ERROR_BODY", True)
+
+    response = request(build_app(), "GET", "/boom")
+
+    entry = captured_logs.messages("Request Completed")[0]
+    assert entry["status_code"] == 500
+    assert entry["response_body"] == response.body.decode()
+    assert '"INTERNAL_ERROR"' in entry["response_body"]
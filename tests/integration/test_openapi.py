@@ -0,0 +1,47 @@
+from fastapi.routing import APIRoute
+
+from src.api.app import create_app
+from src.api.schemas import BODY_ERROR_RESPONSES, COMMON_ERROR_RESPONSES
+
+
+def operations(app):
+    spec = app.openapi()
+    for route in app.routes:
+        if isinstance(route, APIRoute) and route.include_in_schema:
+            for method in route.methods:
+                yield route, method, spec["paths"].get(route.path_format, {}).get(method.lower())
+
+
+def test_every_route_is_in_the_spec():
+    app = create_app()
+
+    missing = [f"{method} {route.path_format}" for route, method, operation in operations(app) if operation is None]
+
+    assert missing == []
+
+
+def test_every_route_documents_common_errors_and_no_422():
+    for route, method, operation in operations(create_app()):
+        statuses = set(operation["responses"])
+        assert "422" not in statuses, f"{method} {route.path_format}"
+        assert {str(s) for s in COMMON_ERROR_RESPONSES} <= statuses, f"{method} {route.path_format}"
+
+
+def test_only_routes_with_a_body_document_413_and_415():
+    for route, method, operation in operations(create_app()):
+        has_body = set(BODY_ERROR_RESPONSES) <= set(operation["responses"])
+        assert has_body == ("requestBody" in operation), f"{method} {route.path_format}"
+
+
+def test_versioned_routes_document_supported_versions_on_404():
+    app = create_app()
+    spec = app.openapi()
+    prefix = app.state.api_versions.prefix("v1")
+
+    for route, method, operation in operations(app):
+        if route.path_format.startswith(prefix):
+            ref = operation["responses"]["404"]["content"]["application/json"]["schema"]["$ref"]
+            assert ref.rsplit("/", 1)[-1] == "NotFoundResponse"
+
+    assert "supported_versions" in spec["components"]["schemas"]["NotFoundResponse"]["properties"]
+    assert "HTTPValidationError" not in spec["components"]["schemas"]
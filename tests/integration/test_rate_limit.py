@@ -0,0 +1,55 @@
+from src.api.app import create_app
+from src.core.config import settings
+from tests.asgi import request
+
+
+def build_app(monkeypatch, trusted_proxies=()):
+    monkeypatch.setattr(settings, "RATE_LIMIT_ENABLED", True)
+    monkeypatch.setattr(settings, "RATE_LIMIT_READ_PER_MINUTE", 2)
+    monkeypatch.setattr(settings, "RATE_LIMIT_TRUSTED_PROXIES", list(trusted_proxies))
+    return create_app()
+
+
+def test_requests_over_the_limit_get_429(monkeypatch):
+    app = build_app(monkeypatch)
+
+    responses = [request(app, "GET", "/") for _ in range(3)]
+
+    assert [r.status for r in responses] == [200, 200, 429]
+    assert responses[1].headers["x-ratelimit-remaining"] == "0"
+    assert int(responses[2].headers["retry-after"]) >= 1
+    assert responses[2].json()["error"] == "Too Many Requests"
+    assert responses[2].json()["code"] == "RATE_LIMITED"
+
+
+def test_health_probes_are_exempt(monkeypatch):
+    app = build_app(monkeypatch)
+
+    statuses = {request(app, "GET", "/health/live").status for _ in range(5)}
+
+    assert statuses == {200}
+
+
+def test_forwarded_clients_get_separate_buckets_behind_trusted_proxy(monkeypatch):
+    app = build_app(monkeypatch, trusted_proxies=["10.0.0.0/8"])
+
+    for _ in range(2):
+        assert request(app, "GET", "/", client="10.0.0.1", headers={"X-Forwarded-For": "203.0.113.1"}).status == 200
+
+    other = request(app, "GET", "/", client="10.0.0.1", headers={"X-Forwarded-For": "203.0.113.2"})
+    limited = request(app, "GET", "/", client="10.0.0.1", headers={"X-Forwarded-For": "203.0.113.1"})
+
+    assert other.status == 200
+    assert limited.status == 429
+
+
+def test_forwarded_for_is_ignored_from_untrusted_peers(monkeypatch):
+    app = build_app(monkeypatch)
+
+    # A client can't dodge the limit by spoofing a new address each time
+    statuses = [
+        request(app, "GET", "/", client="198.51.100.7", headers={"X-Forwarded-For": f"203.0.113.{i}"}).status
+        for i in range(3)
+    ]
+
+    assert statuses == [200, 200, 429]
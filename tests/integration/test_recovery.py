@@ -0,0 +1,53 @@
+from fastapi import BackgroundTasks
+
+from src.api.app import create_app
+from tests.asgi import request
+
+
+def build_app():
+    app = create_app()
+
+    @app.get("/boom")
+    async def boom():
+        raise RuntimeError("boom")
+
+    @app.get("/background")
+    async def background(tasks: BackgroundTasks):
+        def fail():
+            raise RuntimeError("background boom")
+
+        tasks.add_task(fail)
+        return {"queued": True}
+
+    return app
+
+
+def test_unhandled_exception_returns_json_500():
+    response = request(build_app(), "GET", "/boom")
+
+    assert response.status == 500
+    body = response.json()
+    assert body["error"] == "Internal Server Error"
+    assert body["code"] == "INTERNAL_ERROR"
+    assert body["request_id"] == response.headers["x-request-id"]
+
+
+def test_unhandled_exception_is_not_reraised():
+    # request() would raise if the RuntimeError escaped the app, which is what
+    # makes the server log a second traceback and close the connection.
+    app = build_app()
+    first = request(app, "GET", "/boom")
+    second = request(app, "GET", "/")
+
+    assert first.status == 500
+    assert second.status == 200
+
+
+def test_background_task_failure_keeps_the_response():
+    app = build_app()
+    first = request(app, "GET", "/background")
+    second = request(app, "GET", "/")
+
+    assert first.status == 200
+    assert first.json() == {"queued": True}
+    assert second.status == 200
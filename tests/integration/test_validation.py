@@ -0,0 +1,39 @@
+from pydantic import BaseModel
+
+from src.api.app import create_app
+from src.utils.validators import validate
+from tests.asgi import request
+
+
+class Payload(BaseModel):
+    count: int
+
+
+def build_app():
+    app = create_app()
+
+    @app.get("/domain")
+    async def domain():
+        validate(Payload, {"count": "many"})
+
+    @app.get("/internal")
+    async def internal():
+        # A model built from our own data failing is a bug, not bad input
+        Payload.model_validate({"count": "many"})
+
+    return app
+
+
+def test_validation_failed_returns_400_with_fields():
+    response = request(build_app(), "GET", "/domain")
+
+    assert response.status == 400
+    body = response.json()
+    assert body["code"] == "VALIDATION_ERROR"
+    assert list(body["fields"]) == ["count"]
+
+
+def test_other_pydantic_errors_are_500s():
+    response = request(build_app(), "GET", "/internal")
+
+    assert response.status == 500
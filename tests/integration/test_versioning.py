@@ -0,0 +1,62 @@
+from src.api.app import create_app
+from src.core.config import settings
+from tests.asgi import request
+
+
+def test_openapi_is_served_under_the_v1_prefix():
+    app = create_app()
+
+    response = request(app, "GET", f"{app.state.api_versions.prefix('v1')}/openapi.json")
+
+    assert response.status == 200
+    assert "paths" in response.json()
+
+
+def test_unknown_version_lists_supported_versions():
+    response = request(create_app(), "GET", "/api/v9/admin/log-level")
+
+    assert response.status == 404
+    body = response.json()
+    assert body["supported_versions"] == ["v1"]
+    assert body["code"] == "UNSUPPORTED_VERSION"
+    assert "v9" in body["message"]
+
+
+def test_non_version_segment_is_a_plain_404():
+    response = request(create_app(), "GET", "/api/foo")
+
+    assert response.status == 404
+    assert "supported_versions" not in response.json()
+    assert response.json()["code"] == "NOT_FOUND"
+
+
+SUNSET = "Sat, 01 Nov 2025 00:00:00 GMT"
+
+
+def test_deprecated_version_responses_carry_deprecation_headers(monkeypatch, admin_headers):
+    monkeypatch.setattr(settings, "API_DEPRECATED_VERSIONS", {"v1": SUNSET})
+    app = create_app()
+
+    versioned = request(app, "GET", "/api/v1/admin/log-level", headers=admin_headers)
+    unversioned = request(app, "GET", "/health")
+
+    assert versioned.status == 200
+    assert versioned.headers["deprecation"] == "true"
+    assert versioned.headers["sunset"] == SUNSET
+    assert "deprecation" not in unversioned.headers
+    assert "sunset" not in unversioned.headers
+
+
+def test_deprecation_without_sunset_date(monkeypatch, admin_headers):
+    monkeypatch.setattr(settings, "API_DEPRECATED_VERSIONS", {"v1": ""})
+
+    response = request(create_app(), "GET", "/api/v1/admin/log-level", headers=admin_headers)
+
+    assert response.headers["deprecation"] == "true"
+    assert "sunset" not in response.headers
+
+
+def test_current_versions_are_not_marked_deprecated(admin_headers):
+    response = request(create_app(), "GET", "/api/v1/admin/log-level", headers=admin_headers)
+
+    assert "deprecation" not in response.headers
@@ -0,0 +1,62 @@
+import re
+
+import pytest
+
+from src.core.config import Settings
+
+
+def allowed(settings, origin):
+    # Mirrors how CORSMiddleware combines the exact list and the regex
+    if origin in settings.cors_exact_origins:
+        return True
+    regex = settings.cors_origin_regex
+    return bool(regex and re.fullmatch(regex, origin))
+
+
+def test_wildcard_matches_any_depth_of_subdomain():
+    settings = Settings(BACKEND_CORS_ORIGINS=["https://*.example.com"])
+
+    assert allowed(settings, "https://a.example.com")
+    assert allowed(settings, "https://a.b.example.com")
+
+
+def test_wildcard_does_not_match_apex_or_lookalikes():
+    settings = Settings(BACKEND_CORS_ORIGINS=["https://*.example.com"])
+
+    assert not allowed(settings, "https://example.com")
+    assert not allowed(settings, "https://evil.com/.example.com")
+    assert not allowed(settings, "https://a.example.com.evil.com")
+    assert not allowed(settings, "http://a.example.com")
+
+
+def test_exact_and_wildcard_origins_mix():
+    settings = Settings(BACKEND_CORS_ORIGINS="http://localhost:3000,https://*.example.com")
+
+    assert settings.cors_exact_origins == ["http://localhost:3000"]
+    assert allowed(settings, "http://localhost:3000")
+    assert allowed(settings, "https://app.example.com")
+    assert not allowed(settings, "http://localhost:8000")
+
+
+def test_no_wildcards_means_no_regex():
+    settings = Settings(BACKEND_CORS_ORIGINS=["http://localhost:3000"])
+
+    assert settings.cors_origin_regex is None
+
+
+def test_any_origin_with_credentials_is_rejected():
+    with pytest.raises(ValueError, match="cannot contain"):
+        Settings(BACKEND_CORS_ORIGINS=["*"], BACKEND_CORS_ALLOW_CREDENTIALS=True)
+
+
+def test_any_origin_without_credentials_is_allowed():
+    settings = Settings(BACKEND_CORS_ORIGINS=["*"], BACKEND_CORS_ALLOW_CREDENTIALS=False)
+
+    assert settings.cors_exact_origins == ["*"]
+
+
+def test_log_rotation_cannot_be_disabled_by_zero():
+    with pytest.raises(ValueError):
+        Settings(LOG_MAX_BACKUPS=0)
+    with pytest.raises(ValueError):
+        Settings(LOG_MAX_SIZE_MB=0)
@@ -0,0 +1,66 @@
+import asyncio
+
+from src.core.health import HealthRegistry
+
+
+async def ok():
+    pass
+
+
+async def failing():
+    raise ConnectionError("database unreachable")
+
+
+async def hanging():
+    await asyncio.sleep(1)
+
+
+def test_not_ready_until_started():
+    registry = HealthRegistry()
+
+    ready, _ = asyncio.run(registry.run())
+    assert not ready
+
+    asyncio.run(registry.start())
+    ready, _ = asyncio.run(registry.run())
+    assert ready
+
+
+def test_stop_marks_draining():
+    registry = HealthRegistry()
+    asyncio.run(registry.start())
+
+    asyncio.run(registry.stop())
+    ready, _ = asyncio.run(registry.run())
+
+    assert registry.draining
+    assert not ready
+
+
+def test_failing_check_makes_instance_not_ready():
+    registry = HealthRegistry()
+    registry.register("cache", ok)
+    registry.register("database", failing)
+    registry.mark_ready()
+
+    ready, checks = asyncio.run(registry.run())
+
+    assert not ready
+    assert checks["cache"]["status"] == "ok"
+    assert checks["database"] == {
+        "status": "error",
+        "error": "database unreachable",
+        "duration_ms": checks["database"]["duration_ms"],
+    }
+
+
+def test_slow_check_times_out():
+    registry = HealthRegistry(timeout=0.05)
+    registry.register("upstream", hanging)
+    registry.mark_ready()
+
+    ready, checks = asyncio.run(registry.run())
+
+    assert not ready
+    assert checks["upstream"]["error"] == "timed out after 0.05s"
+    assert checks["upstream"]["duration_ms"] < 1000
@@ -0,0 +1,102 @@
+import asyncio
+
+import pytest
+
+from src.core.lifecycle import Lifecycle
+
+
+class FakeComponent:
+    def __init__(self, name, events, fail_start=False, stop_delay=0.0):
+        self.name = name
+        self.events = events
+        self.fail_start = fail_start
+        self.stop_delay = stop_delay
+
+    async def start(self):
+        if self.fail_start:
+            raise RuntimeError(f"{self.name} failed")
+        self.events.append(f"start:{self.name}")
+
+    async def stop(self):
+        await asyncio.sleep(self.stop_delay)
+        self.events.append(f"stop:{self.name}")
+
+
+def register(lifecycle, component, stop_timeout=None):
+    lifecycle.register(component.name, start=component.start, stop=component.stop, stop_timeout=stop_timeout)
+
+
+def test_starts_in_order_and_stops_in_reverse():
+    events = []
+    lifecycle = Lifecycle()
+    for name in ("db", "cache", "http"):
+        register(lifecycle, FakeComponent(name, events))
+
+    asyncio.run(lifecycle.start())
+    asyncio.run(lifecycle.stop())
+
+    assert events == [
+        "start:db", "start:cache", "start:http",
+        "stop:http", "stop:cache", "stop:db",
+    ]
+
+
+def test_failed_start_unwinds_started_components():
+    events = []
+    lifecycle = Lifecycle()
+    register(lifecycle, FakeComponent("db", events))
+    register(lifecycle, FakeComponent("cache", events))
+    register(lifecycle, FakeComponent("workers", events, fail_start=True))
+    register(lifecycle, FakeComponent("http", events))
+
+    with pytest.raises(RuntimeError, match="workers failed"):
+        asyncio.run(lifecycle.start())
+
+    assert events == ["start:db", "start:cache", "stop:cache", "stop:db"]
+
+
+def test_stop_is_idempotent():
+    events = []
+    lifecycle = Lifecycle()
+    register(lifecycle, FakeComponent("db", events))
+
+    asyncio.run(lifecycle.start())
+    asyncio.run(lifecycle.stop())
+    asyncio.run(lifecycle.stop())
+
+    assert events == ["start:db", "stop:db"]
+
+
+def test_slow_stop_times_out_and_shutdown_continues():
+    events = []
+    lifecycle = Lifecycle(stop_timeout=0.05)
+    register(lifecycle, FakeComponent("db", events))
+    register(lifecycle, FakeComponent("workers", events, stop_delay=1.0))
+
+    asyncio.run(lifecycle.start())
+    asyncio.run(lifecycle.stop())
+
+    # The slow component is abandoned, the next one still stops
+    assert events == ["start:db", "start:workers", "stop:db"]
+
+
+def test_component_timeout_overrides_default():
+    events = []
+    lifecycle = Lifecycle(stop_timeout=0.01)
+    register(lifecycle, FakeComponent("workers", events, stop_delay=0.05), stop_timeout=1.0)
+
+    asyncio.run(lifecycle.start())
+    asyncio.run(lifecycle.stop())
+
+    assert events == ["start:workers", "stop:workers"]
+
+
+def test_zero_timeout_waits_without_limit():
+    events = []
+    lifecycle = Lifecycle(stop_timeout=0.01)
+    register(lifecycle, FakeComponent("workers", events, stop_delay=0.05), stop_timeout=0)
+
+    asyncio.run(lifecycle.start())
+    asyncio.run(lifecycle.stop())
+
+    assert events == ["start:workers", "stop:workers"]
@@ -0,0 +1,263 @@
+import json
+import logging
+import os
+import time
+
+from src.core.config import settings
+from src.utils.logger import (
+    JsonFormatter,
+    RotatingFileHandler,
+    SamplingFilter,
+    get_level,
+    get_suppressed_count,
+    request_id_var,
+    set_level,
+    setup_logging,
+)
+
+
+def make_record(msg, level=logging.INFO, exc_info=None):
+    return logging.LogRecord("alya.io", level, __file__, 10, msg, None, exc_info, func="handler")
+
+
+def format_json(msg, **kwargs):
+    return json.loads(JsonFormatter().format(make_record(msg, **kwargs)))
+
+
+def test_json_fixed_keys_come_first_then_fields_in_insertion_order():
+    entry = format_json({"message": "hello", "zeta": 1, "alpha": 2})
+
+    assert list(entry) == ["ts", "level", "logger", "caller", "msg", "zeta", "alpha"]
+    assert entry["msg"] == "hello"
+    assert entry["level"] == "info"
+
+
+def test_json_colliding_fields_are_namespaced_not_dropped():
+    entry = format_json({"message": "Log level changed", "level": "debug", "caller": "me"})
+
+    assert entry["level"] == "info"
+    assert entry["fields.level"] == "debug"
+    assert entry["fields.caller"] == "me"
+
+
+def test_json_uses_request_id_from_context():
+    token = request_id_var.set("req-1")
+    try:
+        entry = format_json({"message": "hello"})
+    finally:
+        request_id_var.reset(token)
+
+    assert entry["request_id"] == "req-1"
+
+
+def test_json_explicit_request_id_wins_over_context():
+    token = request_id_var.set("req-1")
+    try:
+        entry = format_json({"message": "hello", "request_id": "req-2"})
+    finally:
+        request_id_var.reset(token)
+
+    assert entry["request_id"] == "req-2"
+
+
+def test_json_falls_back_to_str_for_unserializable_values():
+    class Opaque:
+        def __str__(self):
+            return "opaque"
+
+    entry = format_json({"message": "hello", "value": Opaque()})
+
+    assert entry["value"] == "opaque"
+
+
+def test_json_includes_stack_for_exceptions():
+    try:
+        raise ValueError("bad")
+    except ValueError:
+        import sys
+        entry = format_json("failed", level=logging.ERROR, exc_info=sys.exc_info())
+
+    assert entry["msg"] == "failed"
+    assert entry["stack"][-1] == "ValueError: bad"
+
+
+def test_sampling_applies_once_across_handlers(tmp_path):
+    # The file handler must see the same records as stdout, not lose them to
+    # a filter that already counted each record once.
+    log_file = tmp_path / "app.log"
+    options = settings.model_copy(update={"LOG_FILE": str(log_file), "LOG_FORMAT": "json", "LOG_SAMPLING_WINDOW": 60})
+    try:
+        log = setup_logging(options)
+        for _ in range(2):
+            log.info("sampled")
+        for handler in log.handlers:
+            handler.flush()
+
+        lines = log_file.read_text().splitlines()
+        assert [json.loads(line)["msg"] for line in lines] == ["sampled"]
+        assert get_suppressed_count() == 1
+    finally:
+        setup_logging()
+
+
+def test_setup_logging_reads_level_from_settings():
+    try:
+        log = setup_logging(settings.model_copy(update={"LOG_LEVEL": "warning"}))
+        assert log.level == logging.WARNING
+    finally:
+        setup_logging()
+
+
+def test_set_level_applies_to_child_loggers():
+    previous = get_level()
+    child = logging.getLogger("alya.io.child")
+    try:
+        set_level("error")
+        assert not child.isEnabledFor(logging.WARNING)
+        assert child.isEnabledFor(logging.ERROR)
+
+        set_level("debug")
+        assert child.isEnabledFor(logging.DEBUG)
+    finally:
+        set_level(previous)
+
+
+def sampled_logger(name, window, capture):
+    log = logging.getLogger(name)
+    log.propagate = False
+    log.setLevel(logging.INFO)
+    log.handlers = [capture]
+    sampling = SamplingFilter(window=window)
+    log.filters = [sampling]
+    return log, sampling
+
+
+def test_sampling_collapses_messages_differing_only_by_request_id(log_capture):
+    log, sampling = sampled_logger("test.sampling.fields", 60, log_capture)
+
+    for i in range(3):
+        log.info({"message": "Cache miss", "key": "videos", "request_id": f"req-{i}"})
+
+    assert log_capture.messages() == [{"message": "Cache miss", "key": "videos", "request_id": "req-0"}]
+    assert sampling.suppressed_total == 2
+
+
+def test_sampling_keeps_distinct_fields_apart(log_capture):
+    log, sampling = sampled_logger("test.sampling.distinct", 60, log_capture)
+
+    for key in ("videos", "notes", "videos"):
+        log.info({"message": "Cache miss", "key": key})
+
+    assert [m["key"] for m in log_capture.messages()] == ["videos", "notes"]
+    assert sampling.suppressed_total == 1
+
+
+def test_access_log_records_are_never_sampled(log_capture):
+    log, sampling = sampled_logger("test.sampling.access", 60, log_capture)
+
+    for status in (200, 200, 500):
+        log.info({"message": "Request Completed", "status_code": status}, extra={"access": True})
+
+    assert [m["status_code"] for m in log_capture.messages()] == [200, 200, 500]
+    assert sampling.suppressed_total == 0
+
+
+def test_sampling_keeps_call_sites_apart(log_capture):
+    log, _ = sampled_logger("test.sampling.sites", 60, log_capture)
+
+    log.info("same text")
+    log.info("same text")
+
+    assert len(log_capture.records) == 2
+
+
+def test_summary_is_emitted_when_window_closes(log_capture):
+    log, _ = sampled_logger("test.sampling.window", 0.05, log_capture)
+
+    for _ in range(3):
+        log.info("repeated")
+    time.sleep(0.06)
+    log.info("something else")
+
+    summary = log_capture.records[1].msg
+    assert summary["message"] == "Suppressed repeated log messages"
+    assert summary["sampled_message"] == "repeated"
+    assert summary["suppressed"] == 2
+    assert log_capture.records[2].msg == "something else"
+
+
+def test_flush_reports_pending_counts(log_capture):
+    log, sampling = sampled_logger("test.sampling.flush", 60, log_capture)
+
+    for _ in range(2):
+        log.info("repeated")
+    sampling.flush()
+
+    assert [r.msg.get("suppressed") for r in log_capture.records[1:]] == [1]
+
+
+def write(handler, text):
+    handler.emit(make_record(text))
+    handler.flush()
+
+
+def test_file_is_recreated_after_external_delete(tmp_path):
+    path = tmp_path / "app.log"
+    handler = RotatingFileHandler(str(path))
+    try:
+        write(handler, "before")
+        path.unlink()
+        write(handler, "after")
+    finally:
+        handler.close()
+
+    assert path.read_text().splitlines()[-1].endswith("after")
+    assert "before" not in path.read_text()
+
+
+def test_file_is_reopened_after_external_move(tmp_path):
+    path = tmp_path / "app.log"
+    moved = tmp_path / "app.log.archived"
+    handler = RotatingFileHandler(str(path))
+    try:
+        write(handler, "before")
+        path.rename(moved)
+        write(handler, "after")
+    finally:
+        handler.close()
+
+    assert "after" not in moved.read_text()
+    assert "after" in path.read_text()
+
+
+def test_rollover_keeps_backup_count(tmp_path):
+    path = tmp_path / "app.log"
+    handler = RotatingFileHandler(str(path), max_bytes=200, backup_count=2)
+    try:
+        for i in range(20):
+            write(handler, f"line {i} " + "x" * 60)
+    finally:
+        handler.close()
+
+    assert sorted(p.name for p in tmp_path.iterdir()) == ["app.log", "app.log.1", "app.log.2"]
+
+
+def test_rollover_prunes_backups_older_than_max_age(tmp_path):
+    path = tmp_path / "app.log"
+    handler = RotatingFileHandler(str(path), max_bytes=200, backup_count=5, max_age_days=1)
+    try:
+        for i in range(6):
+            write(handler, f"line {i} " + "x" * 60)
+        backups = sorted(p.name for p in tmp_path.iterdir() if p.name != "app.log")
+        assert len(backups) >= 2
+
+        # Age the newest backup past the cutoff; the next rollover shifts it
+        # to .2 and must remove it.
+        week_ago = time.time() - 7 * 86400
+        os.utime(tmp_path / "app.log.1", (week_ago, week_ago))
+        handler.doRollover()
+    finally:
+        handler.close()
+
+    assert not (tmp_path / "app.log.2").exists()
+    assert (tmp_path / "app.log.1").exists()
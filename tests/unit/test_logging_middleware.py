@@ -0,0 +1,93 @@
+import uuid
+
+from src.api.middleware.logging import LoggingMiddleware
+from tests.asgi import request
+
+
+def stub_app(status=200, chunks=(b"ok",)):
+    async def app(scope, receive, send):
+        await send({"type": "http.response.start", "status": status, "headers": []})
+        for i, chunk in enumerate(chunks):
+            await send({"type": "http.response.body", "body": chunk, "more_body": i < len(chunks) - 1})
+
+    return app
+
+
+def completed(captured_logs):
+    return captured_logs.messages("Request Completed")
+
+
+def test_well_formed_request_id_is_echoed(captured_logs):
+    response = request(LoggingMiddleware(stub_app()), "GET", "/", headers={"X-Request-ID": "client-abc.123_x"})
+
+    assert response.headers["x-request-id"] == "client-abc.123_x"
+    assert completed(captured_logs)[0]["request_id"] == "client-abc.123_x"
+
+
+def test_malformed_or_oversized_request_id_is_replaced():
+    for incoming in ("has spaces", "bad\\nline", "<script>", "a" * 129):
+        response = request(LoggingMiddleware(stub_app()), "GET", "/", headers={"X-Request-ID": incoming})
+
+        replaced = response.headers["x-request-id"]
+        assert replaced != incoming
+        assert uuid.UUID(replaced)
+
+
+def test_missing_request_id_is_generated():
+    response = request(LoggingMiddleware(stub_app()), "GET", "/")
+
+    assert uuid.UUID(response.headers["x-request-id"])
+
+
+def test_each_request_is_logged_once(captured_logs):
+    request(LoggingMiddleware(stub_app()), "GET", "/videos", headers={"User-Agent": "tests"})
+
+    assert len(captured_logs.records) == 1
+    entry = completed(captured_logs)[0]
+    assert entry["method"] == "GET"
+    assert entry["status_code"] == 200
+    assert entry["user_agent"] == "tests"
+
+
+def test_response_size_counts_every_streamed_chunk(captured_logs):
+    app = LoggingMiddleware(stub_app(chunks=(b"abc", b"defg", b"")))
+
+    request(app, "GET", "/stream")
+
+    assert completed(captured_logs)[0]["response_size"] == 7
+
+
+def test_server_error_body_is_captured_when_enabled(captured_logs):
+    app = LoggingMiddleware(stub_app(status=500, chunks=(b'{"error":', b'"boom"}')), capture_error_body=True)
+
+    request(app, "GET", "/boom")
+
+    assert completed(captured_logs)[0]["response_body"] == '{"error":"boom"}'
+
+
+def test_captured_body_is_truncated_to_the_limit(captured_logs):
+    app = LoggingMiddleware(
+        stub_app(status=503, chunks=(b"x" * 6, b"y" * 6)),
+        capture_error_body=True,
+        max_body_capture=8,
+    )
+
+    request(app, "GET", "/busy")
+
+    entry = completed(captured_logs)[0]
+    assert entry["response_body"] == "xxxxxxyy"
+    assert entry["response_size"] == 12
+
+
+def test_client_errors_are_never_captured(captured_logs):
+    app = LoggingMiddleware(stub_app(status=404, chunks=(b"missing",)), capture_error_body=True)
+
+    request(app, "GET", "/missing")
+
+    assert "response_body" not in completed(captured_logs)[0]
+
+
+def test_capture_is_off_by_default(captured_logs):
+    request(LoggingMiddleware(stub_app(status=500, chunks=(b"boom",))), "GET", "/boom")
+
+    assert "response_body" not in completed(captured_logs)[0]
@@ -0,0 +1,40 @@
+from src.api.middleware.recovery import RecoveryMiddleware
+from tests.asgi import request
+
+
+def body_messages(response):
+    return [m for m in response.messages if m["type"] == "http.response.body"]
+
+
+def test_failure_mid_stream_leaves_body_unterminated():
+    async def app(scope, receive, send):
+        await send({"type": "http.response.start", "status": 200, "headers": []})
+        await send({"type": "http.response.body", "body": b"partial", "more_body": True})
+        raise RuntimeError("stream broke")
+
+    response = request(RecoveryMiddleware(app), "GET", "/stream")
+
+    assert response.status == 200
+    assert [(m["body"], m["more_body"]) for m in body_messages(response)] == [(b"partial", True)]
+
+
+def test_failure_after_response_sends_nothing_more():
+    async def app(scope, receive, send):
+        await send({"type": "http.response.start", "status": 200, "headers": []})
+        await send({"type": "http.response.body", "body": b"ok", "more_body": False})
+        raise RuntimeError("background task failed")
+
+    response = request(RecoveryMiddleware(app), "GET", "/with-task")
+
+    assert response.status == 200
+    assert len(response.messages) == 2
+
+
+def test_failure_before_response_returns_500():
+    async def app(scope, receive, send):
+        raise RuntimeError("boom")
+
+    response = request(RecoveryMiddleware(app), "GET", "/boom")
+
+    assert response.status == 500
+    assert response.json()["code"] == "INTERNAL_ERROR"
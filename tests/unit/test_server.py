@@ -0,0 +1,38 @@
+import signal
+
+import pytest
+import uvicorn
+
+from src.core.config import settings
+from src.core.health import health_registry
+from src.main import Server, app
+
+
+@pytest.fixture
+def server(monkeypatch):
+    monkeypatch.setattr(settings, "SHUTDOWN_DRAIN_DELAY", 30.0)
+    monkeypatch.setattr(health_registry, "draining", False)
+    return Server(uvicorn.Config(app))
+
+
+def test_first_signal_drains_before_exiting(server):
+    server.handle_exit(signal.SIGTERM, None)
+    try:
+        assert health_registry.draining
+        assert not server.should_exit
+        assert server.drain_timer.is_alive()
+    finally:
+        server.drain_timer.cancel()
+
+
+def test_second_signal_exits_gracefully_and_cancels_the_drain(server):
+    server.handle_exit(signal.SIGINT, None)
+    timer = server.drain_timer
+
+    server.handle_exit(signal.SIGINT, None)
+    timer.join(1)
+
+    assert server.should_exit
+    assert not server.force_exit
+    assert not timer.is_alive()
+    assert server.drain_timer is None
@@ -0,0 +1,81 @@
+import asyncio
+
+from src.api.middleware.timeout import TimeoutMiddleware
+from tests.asgi import request
+
+
+def body_messages(response):
+    return [m for m in response.messages if m["type"] == "http.response.body"]
+
+
+def stub_app(delay=0.0, started_delay=None, after_response=None):
+    # Optionally sends headers and a first chunk, waits, then finishes the body
+    async def app(scope, receive, send):
+        if started_delay is not None:
+            await send({"type": "http.response.start", "status": 200, "headers": []})
+            await send({"type": "http.response.body", "body": b"partial", "more_body": True})
+            await asyncio.sleep(started_delay)
+            await send({"type": "http.response.body", "body": b"rest", "more_body": False})
+            return
+
+        await asyncio.sleep(delay)
+        await send({"type": "http.response.start", "status": 200, "headers": []})
+        await send({"type": "http.response.body", "body": b"ok", "more_body": False})
+        if after_response is not None:
+            await after_response()
+
+    return app
+
+
+def test_slow_handler_gets_504_before_headers():
+    app = TimeoutMiddleware(stub_app(delay=1.0), timeout=0.05)
+
+    response = request(app, "GET", "/slow")
+
+    assert response.status == 504
+    assert response.json()["code"] == "TIMEOUT"
+
+
+def test_timeout_after_headers_leaves_body_unterminated():
+    app = TimeoutMiddleware(stub_app(started_delay=1.0), timeout=0.05)
+
+    response = request(app, "GET", "/stream")
+
+    starts = [m for m in response.messages if m["type"] == "http.response.start"]
+    assert [m["status"] for m in starts] == [200]
+    # No late "rest" chunk and no clean end: the client must see truncation
+    assert [(m["body"], m["more_body"]) for m in body_messages(response)] == [(b"partial", True)]
+
+
+def test_handler_finishing_before_deadline_is_untouched():
+    app = TimeoutMiddleware(stub_app(delay=0.01), timeout=0.5)
+
+    response = request(app, "GET", "/fast")
+
+    assert response.status == 200
+    assert response.body == b"ok"
+
+
+def test_zero_override_disables_timeout():
+    app = TimeoutMiddleware(stub_app(delay=0.1), timeout=0.02, route_timeouts={"/exports": 0})
+
+    response = request(app, "GET", "/exports/big")
+
+    assert response.status == 200
+
+
+def test_work_after_response_is_not_cancelled():
+    # Background tasks run in the same app call after the body is sent
+    finished = []
+
+    async def background():
+        await asyncio.sleep(0.1)
+        finished.append(True)
+
+    app = TimeoutMiddleware(stub_app(after_response=background), timeout=0.02)
+
+    response = request(app, "GET", "/with-task")
+
+    assert response.status == 200
+    assert finished == [True]
+    assert len(body_messages(response)) == 1
@@ -0,0 +1,94 @@
+from typing import List, Optional
+
+import pytest
+from pydantic import BaseModel, Field
+
+from src.utils.validators import LanguageCode, ValidationFailed, YouTubeID, validate
+
+
+class Segment(BaseModel):
+    start: float = Field(ge=0)
+    text: str = Field(min_length=1)
+
+
+class Transcript(BaseModel):
+    language: LanguageCode
+    segments: List[Segment]
+
+
+class Video(BaseModel):
+    video_id: YouTubeID
+    title: str = Field(min_length=1)
+    description: Optional[str] = Field(default=None, max_length=10)
+    transcript: Optional[Transcript] = None
+
+
+def test_valid_input_returns_model():
+    data = {"video_id": "dQw4w9WgXcQ", "title": "Talk", "transcript": {"language": "en", "segments": []}}
+
+    video = validate(Video, data)
+
+    assert video.title == "Talk"
+    assert video.transcript.language == "en"
+
+
+def test_nested_errors_use_dotted_paths():
+    data = {
+        "video_id": "dQw4w9WgXcQ",
+        "title": "Talk",
+        "transcript": {"segments": [{"start": 0, "text": "hi"}, {"start": -1, "text": ""}]},
+    }
+
+    with pytest.raises(ValidationFailed) as info:
+        validate(Video, data)
+
+    assert set(info.value.fields) == {
+        "transcript.language",
+        "transcript.segments.1.start",
+        "transcript.segments.1.text",
+    }
+
+
+def test_optional_field_may_be_missing_or_null():
+    assert validate(Video, {"video_id": "dQw4w9WgXcQ", "title": "Talk"}).description is None
+    assert validate(Video, {"video_id": "dQw4w9WgXcQ", "title": "Talk", "description": None}).description is None
+
+
+def test_optional_field_is_validated_when_present():
+    with pytest.raises(ValidationFailed) as info:
+        validate(Video, {"video_id": "dQw4w9WgXcQ", "title": "Talk", "description": "x" * 11})
+
+    assert list(info.value.fields) == ["description"]
+
+
+def test_youtube_id_accepts_eleven_url_safe_characters():
+    assert validate(Video, {"video_id": "a_b-C1d2E3f", "title": "Talk"}).video_id == "a_b-C1d2E3f"
+
+
+def test_youtube_id_rejects_wrong_length_or_characters():
+    for value in ("short", "dQw4w9WgXcQx", "dQw4w9WgXc!", "https://youtu.be/dQw4w9WgXcQ"):
+        with pytest.raises(ValidationFailed) as info:
+            validate(Video, {"video_id": value, "title": "Talk"})
+        assert list(info.value.fields) == ["video_id"]
+
+
+def test_language_code_accepts_subtags_and_normalizes_primary():
+    for value, expected in (("en", "en"), ("EN-US", "en-US"), ("zh-Hant", "zh-Hant"), ("fil", "fil")):
+        transcript = validate(Transcript, {"language": value, "segments": []})
+        assert transcript.language == expected
+
+
+def test_language_code_rejects_malformed_values():
+    for value in ("e", "english", "en_US", "en-", ""):
+        with pytest.raises(ValidationFailed) as info:
+            validate(Transcript, {"language": value, "segments": []})
+        assert list(info.value.fields) == ["language"]
+
+
+def test_custom_types_validate_inside_optional_nested_models():
+    data = {"video_id": "dQw4w9WgXcQ", "title": "Talk", "transcript": {"language": "en_US", "segments": []}}
+
+    with pytest.raises(ValidationFailed) as info:
+        validate(Video, data)
+
+    assert list(info.value.fields) == ["transcript.language"]